@@ -0,0 +1,192 @@
+package dino
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DefaultMultipartMemory is the maximum number of bytes of a multipart body
+// kept in memory by the multipart/form-data decoder, the rest goes to disk
+const DefaultMultipartMemory = 32 << 20
+
+// Decoder decodes the body of r into v, which is a pointer
+type Decoder func(r *http.Request, v any) error
+
+var decoders = struct {
+	sync.RWMutex
+	m map[string]Decoder
+}{
+	m: map[string]Decoder{
+		"application/json":                  decodeJSONBody,
+		"application/xml":                   decodeXMLBody,
+		"text/xml":                          decodeXMLBody,
+		"application/x-www-form-urlencoded": decodeFormBody,
+		"multipart/form-data":               decodeMultipartBody,
+	},
+}
+
+// RegisterDecoder registers the Decoder used by Bind for a media type such as
+// "application/msgpack", replacing any decoder registered before
+func RegisterDecoder(mediaType string, d Decoder) {
+	decoders.Lock()
+	defer decoders.Unlock()
+
+	decoders.m[strings.ToLower(mediaType)] = d
+}
+
+// lookupDecoder also matches structured syntax suffixes, so that
+// "application/merge-patch+json" is decoded as JSON unless it has its own
+// decoder
+func lookupDecoder(mediaType string) (Decoder, bool) {
+	decoders.RLock()
+	defer decoders.RUnlock()
+
+	if d, ok := decoders.m[mediaType]; ok {
+		return d, true
+	}
+
+	if i := strings.LastIndexByte(mediaType, '+'); i >= 0 {
+		d, ok := decoders.m["application/"+mediaType[i+1:]]
+		return d, ok
+	}
+
+	return nil, false
+}
+
+func SupportedMediaTypes() []string {
+	decoders.RLock()
+	defer decoders.RUnlock()
+
+	return slices.Sorted(func(yield func(string) bool) {
+		for mediaType := range decoders.m {
+			if !yield(mediaType) {
+				return
+			}
+		}
+	})
+}
+
+// Bind decodes the body of r into a T with the Decoder registered for its
+// Content-Type and validates it with Validate. Bodies of any other type result
+// in a 415 error listing the supported media types
+func Bind[T any](r *http.Request) (T, error) {
+	var v T
+
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return v, unsupportedMediaTypeError(SupportedMediaTypes())
+	}
+
+	d, ok := lookupDecoder(mediaType)
+	if !ok {
+		return v, unsupportedMediaTypeError(SupportedMediaTypes())
+	}
+
+	if err := d(r, &v); err != nil {
+		return v, err
+	}
+
+	if err := newReadConfig().validate(&v); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+func unsupportedMediaTypeError(supported []string) *Error {
+	return NewError(http.StatusUnsupportedMediaType, "Unsupported media type",
+		WithDetails(map[string][]string{"supported": supported}),
+		WithMessageKey("dino.body.unsupported_media_type"),
+	)
+}
+
+func decodeJSONBody(r *http.Request, v any) error {
+	return decodeJSON(r.Body, v, newReadConfig())
+}
+
+func decodeXMLBody(r *http.Request, v any) error {
+	return decodeXML(r.Body, v, newReadConfig())
+}
+
+func decodeFormBody(r *http.Request, v any) error {
+	return decodeForm(r, v, newReadConfig())
+}
+
+func decodeMultipartBody(r *http.Request, v any) error {
+	return decodeMultipart(r, v, newReadConfig())
+}
+
+func decodeForm(r *http.Request, v any, options readConfig) error {
+	if err := parseForm(r, options, r.ParseForm); err != nil {
+		return invalidFormError(err)
+	}
+
+	return bindForm(v, r.PostForm)
+}
+
+func decodeMultipart(r *http.Request, v any, options readConfig) error {
+	err := parseForm(r, options, func() error {
+		return r.ParseMultipartForm(DefaultMultipartMemory)
+	})
+	if err != nil {
+		return invalidFormError(err)
+	}
+
+	return bindForm(v, r.MultipartForm.Value)
+}
+
+// parseForm runs parse with the body of r read through options, so the body
+// limit applies and buffered bodies are read from the start. The body is
+// restored afterwards
+func parseForm(r *http.Request, options readConfig, parse func() error) error {
+	if r.Body == nil {
+		return parse()
+	}
+
+	body := r.Body
+	r.Body = io.NopCloser(options.reader(body))
+	defer func() { r.Body = body }()
+
+	return parse()
+}
+
+// parsePostForm parses the form body of r, if not done yet, ignoring errors
+// like r.PostFormValue does
+func parsePostForm(r *http.Request) {
+	if r.PostForm != nil {
+		return
+	}
+
+	parseForm(r, newReadConfig(), func() error {
+		return r.ParseMultipartForm(DefaultMultipartMemory)
+	})
+}
+
+func invalidFormError(err error) *Error {
+	return readError(err, NewError(http.StatusBadRequest, "invalid form body",
+		WithInternalError(err),
+		WithMessageKey("dino.body.invalid_form"),
+	))
+}
+
+// bindForm fills the fields of the struct pointed to by v that have a `form`
+// tag, see BindParams for the supported tags
+func bindForm(v any, values url.Values) error {
+	source := paramSource{tag: "form", from: fromForm, lookup: lookupValues(values)}
+
+	verr, err := bindStruct(v, source)
+	if err != nil {
+		return err
+	}
+
+	if verr.HasErrors() {
+		return verr.toError(http.StatusBadRequest, "invalid form body", WithMessageKey("dino.body.invalid_form"))
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+package dino
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// paramSource binds the struct fields with a given tag. lookup returns the
+// values of a name, which are empty when it is missing
+type paramSource struct {
+	tag    string
+	from   paramFrom
+	lookup func(name string) []string
+}
+
+func lookupValues(values url.Values) func(name string) []string {
+	return func(name string) []string {
+		return values[name]
+	}
+}
+
+func requestParamSources(r *http.Request) []paramSource {
+	return []paramSource{
+		{tag: "path", from: fromPath, lookup: func(name string) []string {
+			// The router sets path values for every wildcard of the pattern,
+			// so a missing one is an empty value, which is ignored
+			return []string{r.PathValue(name)}
+		}},
+		{tag: "query", from: fromQuery, lookup: lookupValues(r.URL.Query())},
+		{tag: "header", from: fromHeader, lookup: func(name string) []string {
+			return r.Header.Values(name)
+		}},
+		{tag: "cookie", from: fromCookie, lookup: func(name string) []string {
+			c, err := r.Cookie(name)
+			if err != nil {
+				return nil
+			}
+			return []string{c.Value}
+		}},
+	}
+}
+
+// BindParams fills a T, which must be a struct, from the request parameters
+// named by the tags of its fields:
+//
+//	type ListParams struct {
+//		ID      int       `path:"id"`
+//		Page    int       `query:"page" default:"1"`
+//		Tags    []string  `query:"tag"`
+//		Tenant  string    `header:"X-Tenant,required"`
+//		Session string    `cookie:"session"`
+//		Since   time.Time `query:"since" format:"2006-01-02"`
+//	}
+//
+// Values are converted like Param does. Empty values are ignored, like
+// QueryParams does, and parameters without other values keep the zero value,
+// unless the field has a `default` tag or the required option. Slice fields
+// receive every value of a parameter, other fields the first one, and
+// time.Time fields are parsed with the layout of the `format` tag, which
+// defaults to time.RFC3339. Instead of failing on the first bad parameter, a
+// single 400 error listing all of them is returned
+func BindParams[T any](r *http.Request) (T, error) {
+	var v T
+
+	verr, err := bindStruct(&v, requestParamSources(r)...)
+	if err != nil {
+		return v, err
+	}
+
+	if verr.HasErrors() {
+		return v, verr.toError(http.StatusBadRequest, "invalid parameters", WithMessageKey("dino.param.invalid_params"))
+	}
+
+	return v, nil
+}
+
+// bindStruct returns the conversion errors in a ValidationError. The returned
+// error is reserved for programming errors such as unsupported field types
+func bindStruct(v any, sources ...paramSource) (*ValidationError, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("dino: parameters can only be bound to a struct, got %T", v)
+	}
+
+	verr := &ValidationError{}
+
+	if err := bindFields(rv.Elem(), sources, verr); err != nil {
+		return nil, err
+	}
+
+	return verr, nil
+}
+
+func bindFields(rv reflect.Value, sources []paramSource, verr *ValidationError) error {
+	for i := range rv.NumField() {
+		field := rv.Type().Field(i)
+		fv := rv.Field(i)
+
+		source, tag, ok := fieldSource(field, sources)
+
+		if !ok && field.Anonymous && fv.Kind() == reflect.Struct {
+			if err := bindFields(fv, sources, verr); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if !ok || tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(tag, ",")
+		p := Param{from: source.from, name: name}
+
+		// Empty values are ignored like QueryParams does, so "?page=&page=2"
+		// binds 2 and "?tag=a&tag=" binds a single tag
+		values := source.lookup(name)
+		values = slices.DeleteFunc(slices.Clone(values), func(v string) bool { return v == "" })
+
+		if len(values) == 0 {
+			if defaultValue, ok := field.Tag.Lookup("default"); ok {
+				values = []string{defaultValue}
+			} else if slices.Contains(strings.Split(opts, ","), "required") {
+				verr.Collect(p.newError("required", "required", "is required"))
+				continue
+			} else {
+				continue
+			}
+		}
+
+		if err := scanValues(p, values, fv, timeFormat(field)); err != nil {
+			if !verr.Collect(err) {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func fieldSource(field reflect.StructField, sources []paramSource) (paramSource, string, bool) {
+	for _, source := range sources {
+		if tag, ok := field.Tag.Lookup(source.tag); ok {
+			return source, tag, true
+		}
+	}
+	return paramSource{}, "", false
+}
+
+func timeFormat(field reflect.StructField) string {
+	if format, ok := field.Tag.Lookup("format"); ok {
+		return format
+	}
+	return time.RFC3339
+}
+
+// scanValues converts the non-empty values into fv. Slices receive every
+// value, reported as name[i] in errors, while other types receive the first one
+func scanValues(p Param, values []string, fv reflect.Value, timeFormat string) error {
+	_, hasParser := lookupParser(fv.Type())
+	isSlice := fv.Kind() == reflect.Slice && !hasParser && !fv.Addr().Type().Implements(textUnmarshalerType)
+
+	if !isSlice {
+		if len(values) > 0 {
+			p.value = values[0]
+		}
+		return p.scan(fv, timeFormat)
+	}
+
+	slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+
+	var verr ValidationError
+
+	for i, value := range values {
+		elem := Param{from: p.from, name: fmt.Sprintf("%s[%d]", p.name, i), value: value}
+
+		if err := elem.scan(slice.Index(i), timeFormat); err != nil {
+			if !verr.Collect(err) {
+				return err
+			}
+		}
+	}
+
+	if err := verr.Err(); err != nil {
+		return err
+	}
+
+	fv.Set(slice)
+
+	return nil
+}
@@ -0,0 +1,122 @@
+package dino
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testPagination struct {
+	Page  int `query:"page" default:"1"`
+	Limit int `query:"limit" default:"20"`
+}
+
+type testParamsStruct struct {
+	testPagination
+
+	ID      int       `path:"id"`
+	Tags    []string  `query:"tag"`
+	Since   time.Time `query:"since" format:"2006-01-02"`
+	Tenant  string    `header:"X-Tenant,required"`
+	Session string    `cookie:"session"`
+	Ignored string    `query:"-"`
+}
+
+func newParamsRequest(target string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	req.SetPathValue("id", "42")
+	return req
+}
+
+func TestBindParams(t *testing.T) {
+	req := newParamsRequest("/items/42?page=3&tag=a&tag=b&since=2024-02-29&Ignored=x")
+	req.Header.Set("X-Tenant", "acme")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	params, err := BindParams[testParamsStruct](req)
+
+	require.NoError(t, err)
+	assert.Equal(t, testParamsStruct{
+		testPagination: testPagination{Page: 3, Limit: 20},
+		ID:             42,
+		Tags:           []string{"a", "b"},
+		Since:          time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		Tenant:         "acme",
+		Session:        "abc",
+	}, params)
+}
+
+func TestBindParams_EmptyValueUsesDefault(t *testing.T) {
+	req := newParamsRequest("/items/42?page=")
+	req.Header.Set("X-Tenant", "acme")
+
+	params, err := BindParams[testParamsStruct](req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, params.Page)
+}
+
+func TestBindParams_IgnoresEmptyValues(t *testing.T) {
+	req := newParamsRequest("/items/42?page=&page=2&tag=a&tag=&tag=b")
+	req.Header.Set("X-Tenant", "acme")
+
+	params, err := BindParams[testParamsStruct](req)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, params.Page)
+	assert.Equal(t, []string{"a", "b"}, params.Tags)
+}
+
+func TestBindParams_EmptyValuesOfRequired(t *testing.T) {
+	req := newParamsRequest("/items/42")
+	req.Header.Add("X-Tenant", "")
+	req.Header.Add("X-Tenant", "")
+
+	_, err := BindParams[testParamsStruct](req)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+	require.Len(t, verr.Errors, 1)
+	assert.Equal(t, "X-Tenant", verr.Errors[0].Field)
+	assert.Equal(t, "required", verr.Errors[0].Code)
+}
+
+func TestBindParams_AggregatesErrors(t *testing.T) {
+	req := newParamsRequest("/items/42?page=x&limit=y&since=yesterday")
+	req.SetPathValue("id", "abc")
+
+	_, err := BindParams[testParamsStruct](req)
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Equal(t, "invalid parameters", httpErr.Message)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	messages := make(map[string]string)
+	for _, fe := range verr.Errors {
+		messages[fe.Field] = fe.Message
+	}
+
+	assert.Equal(t, map[string]string{
+		"page":     "must be an integer",
+		"limit":    "must be an integer",
+		"id":       "must be an integer",
+		"since":    "must be a time. Example value: " + time.Now().Format("2006-01-02"),
+		"X-Tenant": "is required",
+	}, messages)
+}
+
+func TestBindParams_NotAStruct(t *testing.T) {
+	_, err := BindParams[string](newParamsRequest("/"))
+
+	require.Error(t, err)
+	var httpErr *Error
+	assert.NotErrorAs(t, err, &httpErr)
+}
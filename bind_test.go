@@ -0,0 +1,246 @@
+package dino
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testBindStruct struct {
+	Name  string `json:"name" xml:"name" form:"name"`
+	Email string `json:"email" xml:"email" form:"email"`
+	Age   int    `json:"age" xml:"age" form:"age"`
+}
+
+func newBindRequest(contentType, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(body))
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	return req
+}
+
+func TestBind_ContentTypes(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{"json", "application/json", `{"name":"John","email":"john@example.com","age":30}`},
+		{"json with charset", "application/json; charset=utf-8", `{"name":"John","email":"john@example.com","age":30}`},
+		{"json suffix", "application/vnd.api+json", `{"name":"John","email":"john@example.com","age":30}`},
+		{"xml", "application/xml", `<testBindStruct><name>John</name><email>john@example.com</email><age>30</age></testBindStruct>`},
+		{"text xml", "text/xml", `<testBindStruct><name>John</name><email>john@example.com</email><age>30</age></testBindStruct>`},
+		{"form", "application/x-www-form-urlencoded", `name=John&email=john%40example.com&age=30`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Bind[testBindStruct](newBindRequest(tt.contentType, tt.body))
+
+			require.NoError(t, err)
+			assert.Equal(t, testBindStruct{Name: "John", Email: "john@example.com", Age: 30}, result)
+		})
+	}
+}
+
+func TestBind_Multipart(t *testing.T) {
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+	mw.WriteField("name", "John")
+	mw.WriteField("age", "30")
+	mw.Close()
+
+	result, err := Bind[testBindStruct](newBindRequest(mw.FormDataContentType(), body.String()))
+
+	require.NoError(t, err)
+	assert.Equal(t, testBindStruct{Name: "John", Age: 30}, result)
+}
+
+func TestBind_UnsupportedMediaType(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+	}{
+		{"missing", ""},
+		{"unknown", "text/plain"},
+		{"invalid", "application/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Bind[testBindStruct](newBindRequest(tt.contentType, "data"))
+
+			var httpErr *Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusUnsupportedMediaType, httpErr.Code)
+			assert.Equal(t, map[string][]string{"supported": SupportedMediaTypes()}, httpErr.Details)
+		})
+	}
+}
+
+func TestBind_DecodeErrors(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentType     string
+		body            string
+		expectedMessage string
+	}{
+		{"json", "application/json", `{"name":`, "invalid JSON body"},
+		{"xml", "application/xml", `<testBindStruct>`, "invalid XML body"},
+		{"form", "application/x-www-form-urlencoded", `name=%zz`, "invalid form body"},
+		{"form conversion", "application/x-www-form-urlencoded", `age=old`, "invalid form body"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Bind[testBindStruct](newBindRequest(tt.contentType, tt.body))
+
+			var httpErr *Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Equal(t, tt.expectedMessage, httpErr.Message)
+		})
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	RegisterDecoder("application/x-test", func(r *http.Request, v any) error {
+		data, err := ReadBytes(r.Body)
+		if err != nil {
+			return err
+		}
+		return json.Unmarshal(bytes.ToUpper(data), v)
+	})
+	defer func() {
+		decoders.Lock()
+		delete(decoders.m, "application/x-test")
+		decoders.Unlock()
+	}()
+
+	result, err := Bind[map[string]string](newBindRequest("application/X-Test", `{"key":"value"}`))
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"KEY": "VALUE"}, result)
+	assert.Contains(t, SupportedMediaTypes(), "application/x-test")
+}
+
+type testFormEmbedded struct {
+	Tenant string `form:"tenant"`
+}
+
+type testFormStruct struct {
+	testFormEmbedded
+	Int8     int8          `form:"int8"`
+	Uint16   uint16        `form:"uint16"`
+	Uint     uint          `form:"uint"`
+	Float32  float32       `form:"float32"`
+	Bool     bool          `form:"bool"`
+	Duration time.Duration `form:"duration"`
+	Date     time.Time     `form:"date" format:"2006-01-02"`
+	Time     time.Time     `form:"time"`
+	Addr     netip.Addr    `form:"addr"`
+	Pointer  *int          `form:"pointer"`
+	Tags     []string      `form:"tag"`
+	IDs      []int         `form:"id"`
+	Skipped  string        `form:"-"`
+	Untagged string
+}
+
+func TestBindForm_Types(t *testing.T) {
+	values := url.Values{
+		"tenant":   {"acme"},
+		"int8":     {"-12"},
+		"uint16":   {"65535"},
+		"uint":     {"7"},
+		"float32":  {"1.5"},
+		"bool":     {"true"},
+		"duration": {"1m30s"},
+		"date":     {"2024-02-29"},
+		"time":     {"2024-02-29T10:00:00Z"},
+		"addr":     {"192.168.0.1"},
+		"pointer":  {"5"},
+		"tag":      {"a", "b"},
+		"id":       {"1", "2", "3"},
+		"Skipped":  {"x"},
+		"-":        {"x"},
+		"Untagged": {"x"},
+	}
+
+	var result testFormStruct
+	err := bindForm(&result, values)
+
+	require.NoError(t, err)
+	five := 5
+	assert.Equal(t, testFormStruct{
+		testFormEmbedded: testFormEmbedded{Tenant: "acme"},
+		Int8:             -12,
+		Uint16:           65535,
+		Uint:             7,
+		Float32:          1.5,
+		Bool:             true,
+		Duration:         90 * time.Second,
+		Date:             time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		Time:             time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC),
+		Addr:             netip.MustParseAddr("192.168.0.1"),
+		Pointer:          &five,
+		Tags:             []string{"a", "b"},
+		IDs:              []int{1, 2, 3},
+	}, result)
+}
+
+func TestBindForm_AggregatesErrors(t *testing.T) {
+	values := url.Values{
+		"int8":   {"200"},
+		"uint16": {"-1"},
+		"uint":   {"-1"},
+		"addr":   {"not-an-ip"},
+		"date":   {"yesterday"},
+		"id":     {"1", "x", "3", "y"},
+	}
+
+	var result testFormStruct
+	err := bindForm(&result, values)
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	messages := make(map[string]string)
+	for _, fe := range verr.Errors {
+		messages[fe.Field] = fe.Message
+	}
+
+	assert.Equal(t, "must be an integer between -128 and 127", messages["int8"])
+	assert.Equal(t, "must be an integer between 0 and 65535", messages["uint16"])
+	assert.Equal(t, "must be a non-negative integer", messages["uint"])
+	assert.Equal(t, "is invalid", messages["addr"])
+	assert.Contains(t, messages["date"], "must be a time")
+	assert.Equal(t, "must be an integer", messages["id[1]"])
+	assert.Equal(t, "must be an integer", messages["id[3]"])
+	assert.Len(t, messages, 7)
+}
+
+func TestBindForm_NotAStruct(t *testing.T) {
+	var result map[string]string
+
+	err := bindForm(&result, url.Values{})
+
+	require.Error(t, err)
+	var httpErr *Error
+	assert.False(t, errors.As(err, &httpErr), "programming errors are not client errors")
+}
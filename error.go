@@ -1,19 +1,95 @@
 package dino
 
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 	Details any    `json:"details,omitempty"`
-	err     error  `json:"-"`
-	log     bool   `json:"-"`
+
+	// Problem Details (RFC 9457) members. They are only rendered when the
+	// error is written as a problem document
+	Type       string         `json:"-"`
+	Title      string         `json:"-"`
+	Instance   string         `json:"-"`
+	Extensions map[string]any `json:"-"`
+
+	header   http.Header `json:"-"`
+	key      string      `json:"-"`
+	args     []any       `json:"-"`
+	stack    []uintptr   `json:"-"`
+	err      error       `json:"-"`
+	log      bool        `json:"-"`
+	logLevel *slog.Level `json:"-"`
 }
 
 type ErrorOption func(*Error)
 
+// Error returns only the client-safe message. Use Cause or the %+v verb to
+// inspect the internal error
 func (e *Error) Error() string {
 	return e.Message
 }
 
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Cause() error {
+	return e.err
+}
+
+// Header returns a copy of the response headers attached to the error
+func (e *Error) Header() http.Header {
+	return e.header.Clone()
+}
+
+// SetHeader sets a response header written together with the error, like
+// WithHeader. It is meant for ErrorHookFunc, which receives a copy of the error
+func (e *Error) SetHeader(key, value string) {
+	setErrorHeader(e, key, value)
+}
+
+// SetExtension sets a problem details extension member, like WithExtension.
+// It is meant for ErrorHookFunc, which receives a copy of the error
+func (e *Error) SetExtension(key string, value any) {
+	WithExtension(key, value)(e)
+}
+
+func (e *Error) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		io.WriteString(s, e.Message)
+
+		if !s.Flag('+') {
+			return
+		}
+
+		for _, frame := range e.StackTrace() {
+			fmt.Fprintf(s, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+		}
+
+		if e.err != nil {
+			fmt.Fprintf(s, "\ncaused by: %+v", e.err)
+		}
+	case 's':
+		io.WriteString(s, e.Message)
+	case 'q':
+		fmt.Fprintf(s, "%q", e.Message)
+	default:
+		fmt.Fprintf(s, "%%!%c(*dino.Error=%s)", verb, e.Message)
+	}
+}
+
 func NewError(code int, message string, opts ...ErrorOption) *Error {
 	err := &Error{
 		Code:    code,
@@ -21,6 +97,10 @@ func NewError(code int, message string, opts ...ErrorOption) *Error {
 		log:     true,
 	}
 
+	if captureStack.Load() {
+		err.stack = callers()
+	}
+
 	for _, opt := range opts {
 		opt(err)
 	}
@@ -34,9 +114,25 @@ func WithDetails(details any) ErrorOption {
 	}
 }
 
-func WithInternalError(internalErr error) ErrorOption {
+// WithInternalError sets the cause of the error, which is never sent to the
+// client. Several causes are combined with errors.Join
+func WithInternalError(internalErrs ...error) ErrorOption {
 	return func(err *Error) {
-		err.err = internalErr
+		if len(internalErrs) == 1 {
+			err.err = internalErrs[0]
+			return
+		}
+		err.err = errors.Join(internalErrs...)
+	}
+}
+
+// WithStack captures the stack trace even if stack capture is disabled with
+// SetStackCapture
+func WithStack() ErrorOption {
+	return func(err *Error) {
+		if err.stack == nil {
+			err.stack = callers()
+		}
 	}
 }
 
@@ -45,3 +141,92 @@ func WithoutLog() ErrorOption {
 		err.log = false
 	}
 }
+
+// WithLogLevel forces the error to be logged at level, regardless of the
+// ErrorHandler's level policy. It overrides a previous WithoutLog and is
+// overridden by a later one
+func WithLogLevel(level slog.Level) ErrorOption {
+	return func(err *Error) {
+		err.log = true
+		err.logLevel = &level
+	}
+}
+
+func WithType(typeURI string) ErrorOption {
+	return func(err *Error) {
+		err.Type = typeURI
+	}
+}
+
+func WithTitle(title string) ErrorOption {
+	return func(err *Error) {
+		err.Title = title
+	}
+}
+
+func WithInstance(instance string) ErrorOption {
+	return func(err *Error) {
+		err.Instance = instance
+	}
+}
+
+func WithExtension(key string, value any) ErrorOption {
+	return func(err *Error) {
+		if err.Extensions == nil {
+			err.Extensions = make(map[string]any)
+		}
+		err.Extensions[key] = value
+	}
+}
+
+func setErrorHeader(err *Error, key, value string) {
+	if err.header == nil {
+		err.header = make(http.Header)
+	}
+	err.header.Set(key, value)
+}
+
+// WithHeader sets a response header that is written together with the error
+func WithHeader(key, value string) ErrorOption {
+	return func(err *Error) {
+		setErrorHeader(err, key, value)
+	}
+}
+
+// WithHeaders adds all values of h to the response headers written together
+// with the error
+func WithHeaders(h http.Header) ErrorOption {
+	return func(err *Error) {
+		if err.header == nil {
+			err.header = make(http.Header)
+		}
+		for key, values := range h {
+			for _, value := range values {
+				err.header.Add(key, value)
+			}
+		}
+	}
+}
+
+// WithAllow sets the Allow header listing the methods supported by the
+// resource, as required for 405 Method Not Allowed responses
+func WithAllow(methods ...string) ErrorOption {
+	return func(err *Error) {
+		setErrorHeader(err, "Allow", strings.Join(methods, ", "))
+	}
+}
+
+// WithRetryAfter sets the Retry-After header, rounded to whole seconds
+func WithRetryAfter(d time.Duration) ErrorOption {
+	return func(err *Error) {
+		seconds := max(int64(d.Round(time.Second)/time.Second), 0)
+		setErrorHeader(err, "Retry-After", strconv.FormatInt(seconds, 10))
+	}
+}
+
+// WithAuthenticate sets the WWW-Authenticate header, e.g. `Bearer realm="api"`
+func WithAuthenticate(challenge string) ErrorOption {
+	return func(err *Error) {
+		setErrorHeader(err, "WWW-Authenticate", challenge)
+	}
+}
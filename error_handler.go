@@ -0,0 +1,291 @@
+package dino
+
+import (
+	"errors"
+	"log/slog"
+	"maps"
+	"net/http"
+	"sync/atomic"
+)
+
+// ErrorHandler renders the errors returned by a Handler. It is the single
+// place where errors become HTTP responses and log records
+type ErrorHandler interface {
+	HandleError(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request, err error)
+
+func (f ErrorHandlerFunc) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	f(w, r, err)
+}
+
+var globalErrorHandler atomic.Pointer[ErrorHandler]
+
+func init() {
+	SetErrorHandler(NewErrorHandler())
+}
+
+// SetErrorHandler changes the ErrorHandler used by every Handler that does not
+// select its own with Handler.WithErrorHandler
+func SetErrorHandler(eh ErrorHandler) {
+	globalErrorHandler.Store(&eh)
+}
+
+func getErrorHandler() ErrorHandler {
+	return *globalErrorHandler.Load()
+}
+
+type ErrorFormat int32
+
+const (
+	// ErrorFormatJSON renders errors as {"code","message","details"} objects
+	ErrorFormatJSON ErrorFormat = iota
+	// ErrorFormatProblemJSON renders errors as application/problem+json (RFC 9457)
+	ErrorFormatProblemJSON
+	// ErrorFormatProblemXML renders errors as application/problem+xml (RFC 9457)
+	ErrorFormatProblemXML
+)
+
+// ErrorFallbackFunc converts an error that is neither a *Error nor mapped by
+// the ErrorRegistry into one
+type ErrorFallbackFunc func(err error) *Error
+
+// ErrorHookFunc is called with a copy of the error right before it is
+// rendered. It may change the copy or report it to an error tracker. For
+// example, a correlation ID can be sent in a header with Error.SetHeader,
+// which works with every ErrorFormat, or as an extension member with
+// Error.SetExtension, which is only rendered in problem documents
+type ErrorHookFunc func(r *http.Request, err *Error)
+
+// ErrorLevelFunc picks the level used to log an error
+type ErrorLevelFunc func(err *Error) slog.Level
+
+// LevelByStatus logs server errors (5xx) at slog.LevelError, client errors
+// (4xx) at slog.LevelWarn and everything else at slog.LevelInfo
+func LevelByStatus(err *Error) slog.Level {
+	switch {
+	case err.Code >= 500:
+		return slog.LevelError
+	case err.Code >= 400:
+		return slog.LevelWarn
+	default:
+		return slog.LevelInfo
+	}
+}
+
+type errorHandlerConfig struct {
+	logger    *slog.Logger
+	levelFunc ErrorLevelFunc
+	format    ErrorFormat
+	registry  *ErrorRegistry
+	catalog   MessageCatalog
+	fallback  ErrorFallbackFunc
+	hooks     []ErrorHookFunc
+}
+
+func newErrorHandlerConfig(opts ...ErrorHandlerOption) errorHandlerConfig {
+	options := errorHandlerConfig{
+		levelFunc: LevelByStatus,
+		format:    ErrorFormatJSON,
+		registry:  DefaultErrorRegistry,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+type ErrorHandlerOption func(*errorHandlerConfig)
+
+// WithErrorLogger sets the logger used for errors. By default slog.Default()
+// is used at the time the error is logged
+func WithErrorLogger(logger *slog.Logger) ErrorHandlerOption {
+	return func(options *errorHandlerConfig) {
+		options.logger = logger
+	}
+}
+
+// WithErrorLevelFunc sets the policy picking the log level of errors that were
+// not created with WithLogLevel. The default is LevelByStatus
+func WithErrorLevelFunc(fn ErrorLevelFunc) ErrorHandlerOption {
+	return func(options *errorHandlerConfig) {
+		options.levelFunc = fn
+	}
+}
+
+func WithErrorFormat(format ErrorFormat) ErrorHandlerOption {
+	return func(options *errorHandlerConfig) {
+		options.format = format
+	}
+}
+
+// WithErrorRegistry selects the registry consulted for errors that are not a
+// *Error. A nil registry disables the lookup
+func WithErrorRegistry(reg *ErrorRegistry) ErrorHandlerOption {
+	return func(options *errorHandlerConfig) {
+		options.registry = reg
+	}
+}
+
+// WithErrorCatalog translates the messages of errors that carry a message key
+// to the language preferred by the request's Accept-Language header
+func WithErrorCatalog(c MessageCatalog) ErrorHandlerOption {
+	return func(options *errorHandlerConfig) {
+		options.catalog = c
+	}
+}
+
+func WithErrorFallback(fn ErrorFallbackFunc) ErrorHandlerOption {
+	return func(options *errorHandlerConfig) {
+		options.fallback = fn
+	}
+}
+
+func WithErrorHook(fn ErrorHookFunc) ErrorHandlerOption {
+	return func(options *errorHandlerConfig) {
+		options.hooks = append(options.hooks, fn)
+	}
+}
+
+type errorHandler struct {
+	options errorHandlerConfig
+}
+
+// NewErrorHandler returns the ErrorHandler used by default. Without options it
+// renders errors as JSON and logs them with slog.Default() at a level picked by
+// LevelByStatus
+func NewErrorHandler(opts ...ErrorHandlerOption) ErrorHandler {
+	return &errorHandler{options: newErrorHandlerConfig(opts...)}
+}
+
+// errorHandlerWithFormat returns a copy of eh rendering errors in format, or a
+// default ErrorHandler if eh was not created with NewErrorHandler
+func errorHandlerWithFormat(eh ErrorHandler, format ErrorFormat) ErrorHandler {
+	h, ok := eh.(*errorHandler)
+	if !ok {
+		return NewErrorHandler(WithErrorFormat(format))
+	}
+
+	options := h.options
+	options.format = format
+
+	return &errorHandler{options: options}
+}
+
+// This avoids leaking internal error details to the client. The library user
+// should wrap errors in dino.Error to provide proper status codes and messages
+func unknownError(err error, opts ...ErrorOption) *Error {
+	return NewError(http.StatusInternalServerError, "Unknown error occurred",
+		append([]ErrorOption{WithInternalError(err), WithMessageKey("dino.unknown_error")}, opts...)...,
+	)
+}
+
+func (eh *errorHandler) HandleError(w http.ResponseWriter, r *http.Request, err error) {
+	httpErr := eh.resolve(err)
+
+	// Hooks work on a copy so errors shared between requests (e.g. package
+	// level variables) are never modified
+	resolved := *httpErr
+	resolved.Extensions = maps.Clone(httpErr.Extensions)
+	resolved.header = httpErr.header.Clone()
+
+	// Errors converted from other errors are created after the handler
+	// returned, so their stack would point at the ErrorHandler
+	if !errors.As(err, new(*Error)) {
+		resolved.stack = nil
+	}
+
+	for _, hook := range eh.options.hooks {
+		hook(r, &resolved)
+	}
+
+	// Only the rendered copy is translated, so logs keep the original messages
+	rendered := resolved
+	if eh.options.catalog != nil {
+		acceptLanguage := r.Header.Get("Accept-Language")
+
+		rendered.Message = resolved.Localize(eh.options.catalog, acceptLanguage)
+		if verr, ok := resolved.Details.(*ValidationError); ok {
+			rendered.Details = verr.localize(eh.options.catalog, acceptLanguage)
+		}
+	}
+
+	// Headers must be set before the status code is written by the renderer
+	for key, values := range rendered.header {
+		w.Header()[key] = values
+	}
+
+	// The only possible error is if the Details or Extensions fields contain
+	// non-serializable data
+	if err := writeError(w, &rendered, eh.options.format); err != nil {
+		failedMsg := "failed to serialize error details"
+
+		fallback := rendered
+		fallback.Details = failedMsg
+		fallback.Extensions = nil
+
+		eh.logger().LogAttrs(r.Context(), slog.LevelError, failedMsg,
+			slog.Any("error", err),
+			slog.Any("original_error", resolved.err),
+		)
+
+		// Since we overwrite Details and drop Extensions, we ignore the error
+		// here as it will not occur
+		writeError(w, &fallback, eh.options.format)
+	}
+
+	if resolved.log {
+		level := eh.options.levelFunc(&resolved)
+		if resolved.logLevel != nil {
+			level = *resolved.logLevel
+		}
+
+		eh.logger().LogAttrs(r.Context(), level, resolved.Message,
+			slog.Int("code", resolved.Code),
+			slog.Any("details", resolved.Details),
+			slog.Any("error", resolved.err),
+			stackAttr(&resolved),
+		)
+	}
+}
+
+func (eh *errorHandler) logger() *slog.Logger {
+	if eh.options.logger != nil {
+		return eh.options.logger
+	}
+	return slog.Default()
+}
+
+func (eh *errorHandler) resolve(err error) *Error {
+	var httpErr *Error
+
+	if errors.As(err, &httpErr) {
+		return httpErr
+	}
+
+	if eh.options.registry != nil {
+		if httpErr, ok := eh.options.registry.Lookup(err); ok {
+			return httpErr
+		}
+	}
+
+	if eh.options.fallback != nil {
+		if httpErr = eh.options.fallback(err); httpErr != nil {
+			return httpErr
+		}
+	}
+
+	return unknownError(err)
+}
+
+func writeError(w http.ResponseWriter, httpErr *Error, format ErrorFormat) error {
+	switch format {
+	case ErrorFormatProblemJSON:
+		return WriteProblemJSON(w, httpErr.Problem())
+	case ErrorFormatProblemXML:
+		return WriteProblemXML(w, httpErr.Problem())
+	default:
+		return WriteJSON(w, httpErr.Code, httpErr)
+	}
+}
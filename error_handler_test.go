@@ -0,0 +1,367 @@
+package dino
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewErrorHandler_WithErrorFormat(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return NewError(http.StatusNotFound, "not found", WithExtension("id", 42))
+	})
+
+	tests := []struct {
+		name                string
+		format              ErrorFormat
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "json",
+			format:              ErrorFormatJSON,
+			expectedContentType: "application/json",
+			expectedBody:        `{"code":404,"message":"not found"}` + "\n",
+		},
+		{
+			name:                "problem json",
+			format:              ErrorFormatProblemJSON,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","id":42}` + "\n",
+		},
+		{
+			name:                "problem xml",
+			format:              ErrorFormatProblemXML,
+			expectedContentType: "application/problem+xml",
+			expectedBody: `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Not Found</title>` +
+				`<status>404</status><detail>not found</detail><id>42</id></problem>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			handler.WithErrorHandler(NewErrorHandler(WithErrorFormat(tt.format))).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestNewErrorHandler_NonSerializableExtension(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return NewError(http.StatusBadRequest, "test error", WithExtension("ch", make(chan int)))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.WithErrorHandler(NewErrorHandler(WithErrorFormat(ErrorFormatProblemJSON))).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t,
+		`{"type":"about:blank","title":"Bad Request","status":400,"detail":"test error","details":"failed to serialize error details"}`,
+		rec.Body.String(),
+	)
+}
+
+func TestNewErrorHandler_InvalidXMLName(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return NewError(http.StatusBadRequest, "test error", WithExtension("bad key", 1))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.WithErrorHandler(NewErrorHandler(WithErrorFormat(ErrorFormatProblemXML))).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Equal(t,
+		`<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Bad Request</title><status>400</status><detail>test error</detail><details>failed to serialize error details</details></problem>`,
+		rec.Body.String(),
+	)
+}
+
+func TestNewErrorHandler_WithErrorFallback(t *testing.T) {
+	errDomain := errors.New("insufficient funds")
+
+	eh := NewErrorHandler(WithErrorFallback(func(err error) *Error {
+		if errors.Is(err, errDomain) {
+			return NewError(http.StatusPaymentRequired, err.Error(), WithoutLog())
+		}
+		return nil
+	}))
+
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "translated error",
+			err:          errDomain,
+			expectedCode: http.StatusPaymentRequired,
+			expectedBody: `{"code":402,"message":"insufficient funds"}`,
+		},
+		{
+			name:         "untranslated error",
+			err:          errors.New("boom"),
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"code":500,"message":"Unknown error occurred"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			eh.HandleError(rec, req, tt.err)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestNewErrorHandler_WithErrorHook(t *testing.T) {
+	shared := NewError(http.StatusConflict, "conflict", WithExtension("resource", "user"))
+
+	var reported []*Error
+
+	eh := NewErrorHandler(
+		WithErrorFormat(ErrorFormatProblemJSON),
+		WithErrorHook(func(r *http.Request, err *Error) {
+			err.SetExtension("correlation_id", r.Header.Get("X-Request-Id"))
+		}),
+		WithErrorHook(func(r *http.Request, err *Error) {
+			reported = append(reported, err)
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("X-Request-Id", "abc-123")
+	rec := httptest.NewRecorder()
+
+	eh.HandleError(rec, req, shared)
+
+	assert.JSONEq(t,
+		`{"type":"about:blank","title":"Conflict","status":409,"detail":"conflict","resource":"user","correlation_id":"abc-123"}`,
+		rec.Body.String(),
+	)
+	assert.Len(t, reported, 1)
+	assert.Equal(t, map[string]any{"resource": "user"}, shared.Extensions, "shared error must not be modified")
+}
+
+func TestSetErrorHandler(t *testing.T) {
+	previous := getErrorHandler()
+	defer SetErrorHandler(previous)
+
+	SetErrorHandler(ErrorHandlerFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		w.Header().Set("Content-Type", "text/html")
+		w.WriteHeader(http.StatusTeapot)
+		w.Write([]byte("<h1>" + err.Error() + "</h1>"))
+	}))
+
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("custom page")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusTeapot, rec.Code)
+	assert.Equal(t, "text/html", rec.Header().Get("Content-Type"))
+	assert.Equal(t, "<h1>custom page</h1>", rec.Body.String())
+}
+
+func TestHandler_WithErrorHandler_OverridesGlobal(t *testing.T) {
+	called := false
+
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("boom")
+	}).WithErrorHandler(ErrorHandlerFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+		called = true
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	assert.NoError(t, err, "handled errors are not propagated")
+	assert.True(t, called)
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+}
+
+func TestNewErrorHandler_WritesErrorHeaders(t *testing.T) {
+	formats := []ErrorFormat{ErrorFormatJSON, ErrorFormatProblemJSON, ErrorFormatProblemXML}
+
+	for _, format := range formats {
+		handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+			w.Header().Set("Allow", "stale")
+			return NewError(http.StatusMethodNotAllowed, "method not allowed",
+				WithAllow(http.MethodGet, http.MethodPost),
+				WithHeader("Cache-Control", "no-store"),
+			)
+		}).WithErrorHandler(NewErrorHandler(WithErrorFormat(format)))
+
+		req := httptest.NewRequest(http.MethodDelete, "/test", nil)
+		rec := httptest.NewRecorder()
+
+		handler.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+		assert.Equal(t, []string{"GET, POST"}, rec.Header().Values("Allow"))
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+	}
+}
+
+func TestNewErrorHandler_HookHeadersDoNotLeak(t *testing.T) {
+	shared := NewError(http.StatusTooManyRequests, "slow down", WithRetryAfter(time.Minute))
+
+	eh := NewErrorHandler(WithErrorHook(func(r *http.Request, err *Error) {
+		err.SetHeader("Retry-After", "1")
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	eh.HandleError(rec, req, shared)
+
+	assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+	assert.Equal(t, "60", shared.header.Get("Retry-After"))
+}
+
+func newTestErrorLogger() (*slog.Logger, *bytes.Buffer) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	return logger, &buf
+}
+
+func decodeLogRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	var records []map[string]any
+
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var record map[string]any
+		require.NoError(t, dec.Decode(&record))
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func TestNewErrorHandler_LogLevelByStatus(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedLevel string
+	}{
+		{"server error", NewError(http.StatusBadGateway, "upstream failed"), "ERROR"},
+		{"unknown error", errors.New("boom"), "ERROR"},
+		{"client error", NewError(http.StatusNotFound, "not found"), "WARN"},
+		{"forced level", NewError(http.StatusNotFound, "not found", WithLogLevel(slog.LevelDebug)), "DEBUG"},
+		{"forced after without log", NewError(http.StatusNotFound, "not found", WithoutLog(), WithLogLevel(slog.LevelInfo)), "INFO"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, buf := newTestErrorLogger()
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			NewErrorHandler(WithErrorLogger(logger)).HandleError(rec, req, tt.err)
+
+			records := decodeLogRecords(t, buf)
+			require.Len(t, records, 1)
+			assert.Equal(t, tt.expectedLevel, records[0]["level"])
+		})
+	}
+}
+
+func TestNewErrorHandler_WithoutLog(t *testing.T) {
+	logger, buf := newTestErrorLogger()
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := NewError(http.StatusInternalServerError, "quiet", WithLogLevel(slog.LevelError), WithoutLog())
+	NewErrorHandler(WithErrorLogger(logger)).HandleError(rec, req, err)
+
+	assert.Empty(t, decodeLogRecords(t, buf))
+}
+
+func TestNewErrorHandler_WithErrorLevelFunc(t *testing.T) {
+	logger, buf := newTestErrorLogger()
+
+	eh := NewErrorHandler(
+		WithErrorLogger(logger),
+		WithErrorLevelFunc(func(err *Error) slog.Level {
+			return slog.LevelInfo
+		}),
+	)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	eh.HandleError(rec, req, NewError(http.StatusInternalServerError, "failed", WithInternalError(errors.New("cause"))))
+
+	records := decodeLogRecords(t, buf)
+	require.Len(t, records, 1)
+	assert.Equal(t, "INFO", records[0]["level"])
+	assert.Equal(t, "failed", records[0]["msg"])
+	assert.Equal(t, float64(500), records[0]["code"])
+	assert.Equal(t, "cause", records[0]["error"])
+}
+
+func TestLevelByStatus(t *testing.T) {
+	assert.Equal(t, slog.LevelError, LevelByStatus(NewError(http.StatusInternalServerError, "")))
+	assert.Equal(t, slog.LevelWarn, LevelByStatus(NewError(http.StatusBadRequest, "")))
+	assert.Equal(t, slog.LevelInfo, LevelByStatus(NewError(http.StatusNotModified, "")))
+}
+
+func TestNewErrorHandler_HookSetsHeaderInEveryFormat(t *testing.T) {
+	formats := []ErrorFormat{ErrorFormatJSON, ErrorFormatProblemJSON, ErrorFormatProblemXML}
+
+	for _, format := range formats {
+		eh := NewErrorHandler(
+			WithErrorFormat(format),
+			WithErrorHook(func(r *http.Request, err *Error) {
+				err.SetHeader("X-Correlation-Id", r.Header.Get("X-Request-Id"))
+				err.SetExtension("correlation_id", r.Header.Get("X-Request-Id"))
+			}),
+		)
+
+		req := httptest.NewRequest(http.MethodGet, "/test", nil)
+		req.Header.Set("X-Request-Id", "abc")
+		rec := httptest.NewRecorder()
+
+		eh.HandleError(rec, req, NewError(http.StatusNotFound, "x"))
+
+		assert.Equal(t, "abc", rec.Header().Get("X-Correlation-Id"))
+
+		if format == ErrorFormatJSON {
+			assert.JSONEq(t, `{"code":404,"message":"x"}`, rec.Body.String(), "extensions are only rendered in problem documents")
+		} else {
+			assert.Contains(t, rec.Body.String(), "abc")
+		}
+	}
+}
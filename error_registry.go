@@ -0,0 +1,99 @@
+package dino
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+)
+
+// StatusClientClosedRequest is the non-standard status popularized by nginx for
+// requests whose client went away before a response could be sent
+const StatusClientClosedRequest = 499
+
+// ErrorRegistry maps errors that are not a *Error to one, so handlers can
+// return errors such as sql.ErrNoRows or context.DeadlineExceeded directly
+type ErrorRegistry struct {
+	mu       sync.RWMutex
+	mappings []func(err error) *Error
+}
+
+var DefaultErrorRegistry = NewErrorRegistry()
+
+// NewErrorRegistry returns a registry containing the built-in mappings for
+// context.Canceled, context.DeadlineExceeded, *http.MaxBytesError and
+// *ValidationError
+func NewErrorRegistry() *ErrorRegistry {
+	reg := &ErrorRegistry{}
+
+	reg.Register(context.Canceled, StatusClientClosedRequest, "Client closed request",
+		WithoutLog(),
+		WithMessageKey("dino.client_closed_request"),
+	)
+	reg.Register(context.DeadlineExceeded, http.StatusGatewayTimeout, "Request timed out",
+		WithMessageKey("dino.request_timeout"),
+	)
+
+	RegisterErrorFunc(reg, bodyTooLargeError)
+	RegisterErrorFunc(reg, validationHTTPError)
+
+	return reg
+}
+
+func (reg *ErrorRegistry) add(mapping func(err error) *Error) {
+	reg.mu.Lock()
+	defer reg.mu.Unlock()
+
+	reg.mappings = append(reg.mappings, mapping)
+}
+
+// Register maps every error matching target with errors.Is to a *Error with
+// the given code, message and options
+func (reg *ErrorRegistry) Register(target error, code int, message string, opts ...ErrorOption) {
+	reg.add(func(err error) *Error {
+		if !errors.Is(err, target) {
+			return nil
+		}
+		return NewError(code, message, append([]ErrorOption{WithInternalError(err)}, opts...)...)
+	})
+}
+
+// RegisterErrorType maps every error matching T with errors.As to a *Error
+// with the given code, message and options
+func RegisterErrorType[T error](reg *ErrorRegistry, code int, message string, opts ...ErrorOption) {
+	reg.add(func(err error) *Error {
+		var target T
+		if !errors.As(err, &target) {
+			return nil
+		}
+		return NewError(code, message, append([]ErrorOption{WithInternalError(err)}, opts...)...)
+	})
+}
+
+// RegisterErrorFunc maps every error matching T with errors.As using fn. If fn
+// returns nil, the remaining mappings are tried
+func RegisterErrorFunc[T error](reg *ErrorRegistry, fn func(err T) *Error) {
+	reg.add(func(err error) *Error {
+		var target T
+		if !errors.As(err, &target) {
+			return nil
+		}
+		return fn(target)
+	})
+}
+
+// Lookup returns the *Error for err. Mappings registered last take precedence,
+// which allows overriding the built-in ones
+func (reg *ErrorRegistry) Lookup(err error) (*Error, bool) {
+	reg.mu.RLock()
+	defer reg.mu.RUnlock()
+
+	for _, mapping := range slices.Backward(reg.mappings) {
+		if httpErr := mapping(err); httpErr != nil {
+			return httpErr, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,166 @@
+package dino
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorRegistry_BuiltinMappings(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode int
+		expectedLog  bool
+	}{
+		{"context canceled", context.Canceled, StatusClientClosedRequest, false},
+		{"deadline exceeded", context.DeadlineExceeded, http.StatusGatewayTimeout, true},
+		{"wrapped deadline exceeded", fmt.Errorf("query users: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, true},
+		{"max bytes", &http.MaxBytesError{Limit: 1024}, http.StatusRequestEntityTooLarge, true},
+	}
+
+	reg := NewErrorRegistry()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpErr, ok := reg.Lookup(tt.err)
+
+			require.True(t, ok)
+			assert.Equal(t, tt.expectedCode, httpErr.Code)
+			assert.Equal(t, tt.expectedLog, httpErr.log)
+			assert.Equal(t, tt.err, httpErr.err)
+		})
+	}
+}
+
+func TestErrorRegistry_MaxBytesDetails(t *testing.T) {
+	httpErr, ok := NewErrorRegistry().Lookup(&http.MaxBytesError{Limit: 1024})
+
+	require.True(t, ok)
+	assert.Equal(t, map[string]int64{"limit": 1024}, httpErr.Details)
+}
+
+func TestErrorRegistry_Register(t *testing.T) {
+	reg := NewErrorRegistry()
+	reg.Register(sql.ErrNoRows, http.StatusNotFound, "resource not found", WithoutLog())
+
+	httpErr, ok := reg.Lookup(fmt.Errorf("get user: %w", sql.ErrNoRows))
+
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	assert.Equal(t, "resource not found", httpErr.Message)
+	assert.False(t, httpErr.log)
+}
+
+func TestRegisterErrorType(t *testing.T) {
+	reg := NewErrorRegistry()
+	RegisterErrorType[*fs.PathError](reg, http.StatusNotFound, "file not found")
+
+	_, err := os.Open("/does/not/exist")
+	httpErr, ok := reg.Lookup(err)
+
+	require.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+	assert.Equal(t, "file not found", httpErr.Message)
+}
+
+type quotaError struct {
+	remaining int
+}
+
+func (e quotaError) Error() string {
+	return "quota exceeded"
+}
+
+func TestRegisterErrorFunc(t *testing.T) {
+	reg := NewErrorRegistry()
+	RegisterErrorFunc(reg, func(err quotaError) *Error {
+		if err.remaining > 0 {
+			return nil
+		}
+		return NewError(http.StatusTooManyRequests, err.Error(), WithDetails(err.remaining))
+	})
+
+	httpErr, ok := reg.Lookup(quotaError{remaining: 0})
+	require.True(t, ok)
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.Code)
+
+	_, ok = reg.Lookup(quotaError{remaining: 3})
+	assert.False(t, ok)
+}
+
+func TestErrorRegistry_LaterRegistrationsTakePrecedence(t *testing.T) {
+	reg := NewErrorRegistry()
+	reg.Register(context.Canceled, http.StatusServiceUnavailable, "canceled")
+
+	httpErr, ok := reg.Lookup(context.Canceled)
+
+	require.True(t, ok)
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.Code)
+}
+
+func TestErrorRegistry_NoMatch(t *testing.T) {
+	_, ok := NewErrorRegistry().Lookup(errors.New("unknown"))
+
+	assert.False(t, ok)
+}
+
+func TestNewErrorHandler_WithErrorRegistry(t *testing.T) {
+	reg := NewErrorRegistry()
+	reg.Register(sql.ErrNoRows, http.StatusNotFound, "resource not found")
+
+	tests := []struct {
+		name         string
+		registry     *ErrorRegistry
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "custom registry",
+			registry:     reg,
+			expectedCode: http.StatusNotFound,
+			expectedBody: `{"code":404,"message":"resource not found"}`,
+		},
+		{
+			name:         "disabled registry",
+			registry:     nil,
+			expectedCode: http.StatusInternalServerError,
+			expectedBody: `{"code":500,"message":"Unknown error occurred"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			NewErrorHandler(WithErrorRegistry(tt.registry)).HandleError(rec, req, sql.ErrNoRows)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			assert.JSONEq(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestHandler_ServeHTTP_DefaultRegistry(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return context.DeadlineExceeded
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusGatewayTimeout, rec.Code)
+	assert.JSONEq(t, `{"code":504,"message":"Request timed out"}`, rec.Body.String())
+}
@@ -0,0 +1,78 @@
+package dino
+
+import (
+	"log/slog"
+	"reflect"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const maxStackDepth = 32
+
+var captureStack atomic.Bool
+
+var packagePrefix = reflect.TypeFor[Error]().PkgPath() + "."
+
+// SetStackCapture enables capturing the stack trace of every Error when it is
+// created. It is disabled by default because of its cost. Errors that are not
+// a *Error only reach the ErrorHandler after the handler returned, so they
+// have no stack: wrap them with NewError where they happen to get one
+func SetStackCapture(enabled bool) {
+	captureStack.Store(enabled)
+}
+
+func callers() []uintptr {
+	pcs := make([]uintptr, maxStackDepth)
+	n := runtime.Callers(3, pcs)
+	return pcs[:n]
+}
+
+// StackTrace returns the frames captured when the error was created, or nil
+// if stack capture was disabled. Leading frames belonging to this package or
+// the runtime are skipped so the stack starts at the code that created the error
+func (e *Error) StackTrace() []runtime.Frame {
+	if len(e.stack) == 0 {
+		return nil
+	}
+
+	var stack []runtime.Frame
+
+	frames := runtime.CallersFrames(e.stack)
+	for {
+		frame, more := frames.Next()
+
+		// Runtime frames show up when the error is created while recovering
+		// from a panic
+		internal := strings.HasPrefix(frame.Function, packagePrefix) || strings.HasPrefix(frame.Function, "runtime.")
+		if len(stack) > 0 || !internal {
+			stack = append(stack, frame)
+		}
+
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+func stackAttr(e *Error) slog.Attr {
+	stack := e.StackTrace()
+	if len(stack) == 0 {
+		return slog.Attr{}
+	}
+
+	frames := make([]string, len(stack))
+	for i, frame := range stack {
+		frames[i] = frame.Function + " " + frame.File + ":" + strconv.Itoa(frame.Line)
+	}
+
+	return slog.Group("stack",
+		slog.String("function", stack[0].Function),
+		slog.String("file", stack[0].File),
+		slog.Int("line", stack[0].Line),
+		slog.Any("frames", frames),
+	)
+}
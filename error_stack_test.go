@@ -0,0 +1,116 @@
+package dino_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willpinha/dino"
+)
+
+func TestNewError_StackCaptureDisabledByDefault(t *testing.T) {
+	err := dino.NewError(http.StatusInternalServerError, "failed")
+
+	assert.Nil(t, err.StackTrace())
+}
+
+func TestSetStackCapture(t *testing.T) {
+	dino.SetStackCapture(true)
+	defer dino.SetStackCapture(false)
+
+	err := dino.NotFoundf("user %d not found", 42)
+
+	stack := err.StackTrace()
+	require.NotEmpty(t, stack)
+	assert.Equal(t, "github.com/willpinha/dino_test.TestSetStackCapture", stack[0].Function)
+	assert.True(t, strings.HasSuffix(stack[0].File, "error_stack_test.go"))
+}
+
+func TestWithStack(t *testing.T) {
+	err := dino.NewError(http.StatusInternalServerError, "failed", dino.WithStack())
+
+	stack := err.StackTrace()
+	require.NotEmpty(t, stack)
+	assert.Equal(t, "github.com/willpinha/dino_test.TestWithStack", stack[0].Function)
+}
+
+func TestErrorHandler_LogsStack(t *testing.T) {
+	mockHandler := &mockLogHandler{}
+	eh := dino.NewErrorHandler(dino.WithErrorLogger(slog.New(mockHandler)))
+
+	handler := dino.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return dino.NewError(http.StatusInternalServerError, "failed", dino.WithStack())
+	}).WithErrorHandler(eh)
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	require.Len(t, mockHandler.records, 1)
+	attrs := mockHandler.records[0].attrs
+
+	assert.Equal(t, "github.com/willpinha/dino_test.TestErrorHandler_LogsStack.func1", attrs["stack.function"])
+	assert.True(t, strings.HasSuffix(attrs["stack.file"].(string), "error_stack_test.go"))
+	assert.NotZero(t, attrs["stack.line"])
+	assert.NotEmpty(t, attrs["stack.frames"])
+	assert.NotContains(t, rec.Body.String(), "stack")
+}
+
+func TestErrorHandler_NoStackForOtherErrors(t *testing.T) {
+	dino.SetStackCapture(true)
+	defer dino.SetStackCapture(false)
+
+	tests := []struct {
+		name string
+		opts []dino.ErrorHandlerOption
+	}{
+		{"unknown error", nil},
+		{"fallback", []dino.ErrorHandlerOption{dino.WithErrorFallback(func(err error) *dino.Error {
+			return dino.InternalServerError("converted")
+		})}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockHandler := &mockLogHandler{}
+			eh := dino.NewErrorHandler(append(tt.opts, dino.WithErrorLogger(slog.New(mockHandler)))...)
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			// The stack of the error built by the ErrorHandler would not show
+			// where the error happened
+			eh.HandleError(rec, req, errors.New("boom"))
+
+			require.Len(t, mockHandler.records, 1)
+			assert.NotContains(t, mockHandler.records[0].attrs, "stack.function")
+		})
+	}
+}
+
+func TestError_Format_WithStack(t *testing.T) {
+	err := dino.NewError(http.StatusInternalServerError, "failed", dino.WithStack(), dino.WithInternalError(errors.New("cause")))
+
+	formatted := fmt.Sprintf("%+v", err)
+
+	assert.True(t, strings.HasPrefix(formatted, "failed\n\tgithub.com/willpinha/dino_test.TestError_Format_WithStack\n"))
+	assert.True(t, strings.HasSuffix(formatted, "\ncaused by: cause"))
+	assert.Equal(t, "failed", fmt.Sprintf("%v", err), "the stack is only printed with %+v")
+}
+
+func TestError_StackNotSerialized(t *testing.T) {
+	err := dino.NewError(http.StatusInternalServerError, "failed", dino.WithStack())
+
+	data, marshalErr := json.Marshal(err)
+
+	require.NoError(t, marshalErr)
+	assert.Equal(t, `{"code":500,"message":"failed"}`, string(data))
+}
@@ -0,0 +1,202 @@
+package dino
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	DefaultRetryAfter    = 60 * time.Second
+	DefaultAuthChallenge = "Bearer"
+)
+
+// newStatusError builds the error for the status constructors below. The
+// options are applied after the defaults of the status. An empty message
+// defaults to the status text
+func newStatusError(code int, message string, defaults []ErrorOption, opts []ErrorOption) *Error {
+	if message == "" {
+		message = http.StatusText(code)
+	}
+
+	return NewError(code, message, append(defaults, opts...)...)
+}
+
+// The constructors ending in f format their message like fmt.Sprintf. Use the
+// constructors without the suffix and fmt.Sprintf to also give options, e.g.
+//
+//	dino.TooManyRequests(fmt.Sprintf("limit of %d requests exceeded", n), dino.WithRetryAfter(time.Minute))
+
+func BadRequest(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusBadRequest, message, nil, opts)
+}
+
+func BadRequestf(format string, args ...any) *Error {
+	return BadRequest(fmt.Sprintf(format, args...))
+}
+
+// Unauthorized sets WWW-Authenticate to DefaultAuthChallenge unless
+// WithAuthenticate is given
+func Unauthorized(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusUnauthorized, message, []ErrorOption{WithAuthenticate(DefaultAuthChallenge)}, opts)
+}
+
+func Unauthorizedf(format string, args ...any) *Error {
+	return Unauthorized(fmt.Sprintf(format, args...))
+}
+
+func PaymentRequired(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusPaymentRequired, message, nil, opts)
+}
+
+func PaymentRequiredf(format string, args ...any) *Error {
+	return PaymentRequired(fmt.Sprintf(format, args...))
+}
+
+func Forbidden(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusForbidden, message, nil, opts)
+}
+
+func Forbiddenf(format string, args ...any) *Error {
+	return Forbidden(fmt.Sprintf(format, args...))
+}
+
+func NotFound(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusNotFound, message, nil, opts)
+}
+
+func NotFoundf(format string, args ...any) *Error {
+	return NotFound(fmt.Sprintf(format, args...))
+}
+
+func MethodNotAllowed(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusMethodNotAllowed, message, nil, opts)
+}
+
+func MethodNotAllowedf(format string, args ...any) *Error {
+	return MethodNotAllowed(fmt.Sprintf(format, args...))
+}
+
+func NotAcceptable(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusNotAcceptable, message, nil, opts)
+}
+
+func NotAcceptablef(format string, args ...any) *Error {
+	return NotAcceptable(fmt.Sprintf(format, args...))
+}
+
+func RequestTimeout(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusRequestTimeout, message, nil, opts)
+}
+
+func RequestTimeoutf(format string, args ...any) *Error {
+	return RequestTimeout(fmt.Sprintf(format, args...))
+}
+
+func Conflict(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusConflict, message, nil, opts)
+}
+
+func Conflictf(format string, args ...any) *Error {
+	return Conflict(fmt.Sprintf(format, args...))
+}
+
+func Gone(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusGone, message, nil, opts)
+}
+
+func Gonef(format string, args ...any) *Error {
+	return Gone(fmt.Sprintf(format, args...))
+}
+
+func PreconditionFailed(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusPreconditionFailed, message, nil, opts)
+}
+
+func PreconditionFailedf(format string, args ...any) *Error {
+	return PreconditionFailed(fmt.Sprintf(format, args...))
+}
+
+func RequestEntityTooLarge(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusRequestEntityTooLarge, message, nil, opts)
+}
+
+func RequestEntityTooLargef(format string, args ...any) *Error {
+	return RequestEntityTooLarge(fmt.Sprintf(format, args...))
+}
+
+func UnsupportedMediaType(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusUnsupportedMediaType, message, nil, opts)
+}
+
+func UnsupportedMediaTypef(format string, args ...any) *Error {
+	return UnsupportedMediaType(fmt.Sprintf(format, args...))
+}
+
+func UnprocessableEntity(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusUnprocessableEntity, message, nil, opts)
+}
+
+func UnprocessableEntityf(format string, args ...any) *Error {
+	return UnprocessableEntity(fmt.Sprintf(format, args...))
+}
+
+func PreconditionRequired(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusPreconditionRequired, message, nil, opts)
+}
+
+func PreconditionRequiredf(format string, args ...any) *Error {
+	return PreconditionRequired(fmt.Sprintf(format, args...))
+}
+
+// TooManyRequests sets Retry-After to DefaultRetryAfter unless WithRetryAfter
+// is given
+func TooManyRequests(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusTooManyRequests, message, []ErrorOption{WithRetryAfter(DefaultRetryAfter)}, opts)
+}
+
+func TooManyRequestsf(format string, args ...any) *Error {
+	return TooManyRequests(fmt.Sprintf(format, args...))
+}
+
+func InternalServerError(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusInternalServerError, message, nil, opts)
+}
+
+func InternalServerErrorf(format string, args ...any) *Error {
+	return InternalServerError(fmt.Sprintf(format, args...))
+}
+
+func NotImplemented(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusNotImplemented, message, nil, opts)
+}
+
+func NotImplementedf(format string, args ...any) *Error {
+	return NotImplemented(fmt.Sprintf(format, args...))
+}
+
+func BadGateway(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusBadGateway, message, nil, opts)
+}
+
+func BadGatewayf(format string, args ...any) *Error {
+	return BadGateway(fmt.Sprintf(format, args...))
+}
+
+// ServiceUnavailable sets Retry-After to DefaultRetryAfter unless
+// WithRetryAfter is given
+func ServiceUnavailable(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusServiceUnavailable, message, []ErrorOption{WithRetryAfter(DefaultRetryAfter)}, opts)
+}
+
+func ServiceUnavailablef(format string, args ...any) *Error {
+	return ServiceUnavailable(fmt.Sprintf(format, args...))
+}
+
+func GatewayTimeout(message string, opts ...ErrorOption) *Error {
+	return newStatusError(http.StatusGatewayTimeout, message, nil, opts)
+}
+
+func GatewayTimeoutf(format string, args ...any) *Error {
+	return GatewayTimeout(fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,133 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusConstructors(t *testing.T) {
+	tests := []struct {
+		name         string
+		constructor  func(message string, opts ...ErrorOption) *Error
+		constructorf func(format string, args ...any) *Error
+		expectedCode int
+	}{
+		{"BadRequest", BadRequest, BadRequestf, http.StatusBadRequest},
+		{"Unauthorized", Unauthorized, Unauthorizedf, http.StatusUnauthorized},
+		{"PaymentRequired", PaymentRequired, PaymentRequiredf, http.StatusPaymentRequired},
+		{"Forbidden", Forbidden, Forbiddenf, http.StatusForbidden},
+		{"NotFound", NotFound, NotFoundf, http.StatusNotFound},
+		{"MethodNotAllowed", MethodNotAllowed, MethodNotAllowedf, http.StatusMethodNotAllowed},
+		{"NotAcceptable", NotAcceptable, NotAcceptablef, http.StatusNotAcceptable},
+		{"RequestTimeout", RequestTimeout, RequestTimeoutf, http.StatusRequestTimeout},
+		{"Conflict", Conflict, Conflictf, http.StatusConflict},
+		{"Gone", Gone, Gonef, http.StatusGone},
+		{"PreconditionFailed", PreconditionFailed, PreconditionFailedf, http.StatusPreconditionFailed},
+		{"RequestEntityTooLarge", RequestEntityTooLarge, RequestEntityTooLargef, http.StatusRequestEntityTooLarge},
+		{"UnsupportedMediaType", UnsupportedMediaType, UnsupportedMediaTypef, http.StatusUnsupportedMediaType},
+		{"UnprocessableEntity", UnprocessableEntity, UnprocessableEntityf, http.StatusUnprocessableEntity},
+		{"PreconditionRequired", PreconditionRequired, PreconditionRequiredf, http.StatusPreconditionRequired},
+		{"TooManyRequests", TooManyRequests, TooManyRequestsf, http.StatusTooManyRequests},
+		{"InternalServerError", InternalServerError, InternalServerErrorf, http.StatusInternalServerError},
+		{"NotImplemented", NotImplemented, NotImplementedf, http.StatusNotImplemented},
+		{"BadGateway", BadGateway, BadGatewayf, http.StatusBadGateway},
+		{"ServiceUnavailable", ServiceUnavailable, ServiceUnavailablef, http.StatusServiceUnavailable},
+		{"GatewayTimeout", GatewayTimeout, GatewayTimeoutf, http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.constructor("something happened")
+
+			assert.Equal(t, tt.expectedCode, err.Code)
+			assert.Equal(t, "something happened", err.Message)
+			assert.True(t, err.log)
+
+			err = tt.constructorf("something happened %d times", 2)
+
+			assert.Equal(t, tt.expectedCode, err.Code)
+			assert.Equal(t, "something happened 2 times", err.Message)
+		})
+	}
+}
+
+func TestStatusConstructors_Options(t *testing.T) {
+	internalErr := errors.New("no rows")
+
+	err := NotFound("user not found", WithInternalError(internalErr), WithoutLog())
+
+	assert.Equal(t, http.StatusNotFound, err.Code)
+	assert.Equal(t, "user not found", err.Message)
+	assert.Equal(t, internalErr, err.err)
+	assert.False(t, err.log)
+}
+
+func TestStatusConstructors_MessageIsNotAFormat(t *testing.T) {
+	err := BadRequest("discount must be below 100%", WithDetails("discount"))
+
+	assert.Equal(t, "discount must be below 100%", err.Message)
+	assert.Equal(t, "discount", err.Details)
+
+	err = BadRequestf("rate %d%%", 100)
+
+	assert.Equal(t, "rate 100%", err.Message)
+}
+
+func TestStatusConstructors_EmptyMessage(t *testing.T) {
+	assert.Equal(t, "Conflict", Conflict("").Message)
+	assert.Equal(t, "Conflict", Conflictf("").Message)
+}
+
+func TestStatusConstructors_DefaultHeaders(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            *Error
+		expectedHeader string
+		expectedValue  string
+	}{
+		{"unauthorized", Unauthorized("login required"), "WWW-Authenticate", "Bearer"},
+		{"too many requests", TooManyRequests("slow down"), "Retry-After", "60"},
+		{"service unavailable", ServiceUnavailable("maintenance"), "Retry-After", "60"},
+		{"formatted", Unauthorizedf("login required for %s", "admin"), "WWW-Authenticate", "Bearer"},
+		{
+			"overridden authenticate",
+			Unauthorized("login required", WithAuthenticate(`Basic realm="admin"`)),
+			"WWW-Authenticate",
+			`Basic realm="admin"`,
+		},
+		{
+			"overridden retry after",
+			TooManyRequests("slow down", WithRetryAfter(1500*time.Millisecond)),
+			"Retry-After",
+			"2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedValue, tt.err.header.Get(tt.expectedHeader))
+		})
+	}
+}
+
+func TestStatusConstructors_HeadersRendered(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return TooManyRequests(fmt.Sprintf("rate limit of %d requests exceeded", 100), WithRetryAfter(30*time.Second))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	require.Equal(t, http.StatusTooManyRequests, rec.Code)
+	assert.Equal(t, "30", rec.Header().Get("Retry-After"))
+	assert.JSONEq(t, `{"code":429,"message":"rate limit of 100 requests exceeded"}`, rec.Body.String())
+}
@@ -1,8 +1,12 @@
 package dino
 
 import (
+	"database/sql"
 	"errors"
+	"fmt"
+	"io/fs"
 	"net/http"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -94,3 +98,106 @@ func TestError_MultipleOptionsOrder(t *testing.T) {
 
 	assert.Equal(t, secondDetails, err.Details)
 }
+
+func TestNewError_WithProblemMembers(t *testing.T) {
+	err := NewError(
+		http.StatusForbidden,
+		"out of credit",
+		WithType("https://example.com/probs/out-of-credit"),
+		WithTitle("You do not have enough credit"),
+		WithInstance("/account/12345"),
+		WithExtension("balance", 30),
+		WithExtension("cost", 50),
+	)
+
+	assert.Equal(t, "https://example.com/probs/out-of-credit", err.Type)
+	assert.Equal(t, "You do not have enough credit", err.Title)
+	assert.Equal(t, "/account/12345", err.Instance)
+	assert.Equal(t, map[string]any{"balance": 30, "cost": 50}, err.Extensions)
+}
+
+func TestError_Unwrap(t *testing.T) {
+	internalErr := sql.ErrNoRows
+
+	err := NewError(http.StatusNotFound, "user not found", WithInternalError(fmt.Errorf("get user: %w", internalErr)))
+
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.Equal(t, "get user: sql: no rows in result set", err.Cause().Error())
+}
+
+func TestError_Unwrap_As(t *testing.T) {
+	_, pathErr := os.Open("/does/not/exist")
+
+	var wrapped error = fmt.Errorf("handler: %w", NewError(http.StatusNotFound, "file not found", WithInternalError(pathErr)))
+
+	var target *fs.PathError
+	require.ErrorAs(t, wrapped, &target)
+	assert.Equal(t, "/does/not/exist", target.Path)
+
+	var httpErr *Error
+	require.ErrorAs(t, wrapped, &httpErr)
+	assert.Equal(t, http.StatusNotFound, httpErr.Code)
+}
+
+func TestError_Unwrap_MultipleCauses(t *testing.T) {
+	errA := errors.New("a")
+	errB := errors.New("b")
+
+	err := NewError(http.StatusInternalServerError, "failed", WithInternalError(errA, errB))
+
+	assert.ErrorIs(t, err, errA)
+	assert.ErrorIs(t, err, errB)
+}
+
+func TestError_Unwrap_NoCause(t *testing.T) {
+	err := NewError(http.StatusBadRequest, "bad request")
+
+	assert.Nil(t, err.Unwrap())
+	assert.Nil(t, err.Cause())
+}
+
+func TestError_Format(t *testing.T) {
+	inner := NewError(http.StatusBadGateway, "upstream failed", WithInternalError(errors.New("connection refused")))
+	err := NewError(http.StatusInternalServerError, "request failed", WithInternalError(inner))
+
+	assert.Equal(t, "request failed", err.Error())
+	assert.Equal(t, "request failed", fmt.Sprintf("%v", err))
+	assert.Equal(t, "request failed", fmt.Sprintf("%s", err))
+	assert.Equal(t, `"request failed"`, fmt.Sprintf("%q", err))
+	assert.Equal(t, "request failed\ncaused by: upstream failed\ncaused by: connection refused", fmt.Sprintf("%+v", err))
+}
+
+func TestNewError_WithHeader(t *testing.T) {
+	err := NewError(
+		http.StatusMethodNotAllowed,
+		"method not allowed",
+		WithHeader("X-Reason", "first"),
+		WithHeader("X-Reason", "second"),
+		WithAllow(http.MethodGet, http.MethodHead),
+	)
+
+	assert.Equal(t, []string{"second"}, err.header.Values("X-Reason"))
+	assert.Equal(t, "GET, HEAD", err.header.Get("Allow"))
+}
+
+func TestNewError_WithHeaders(t *testing.T) {
+	err := NewError(
+		http.StatusNotFound,
+		"not found",
+		WithHeaders(http.Header{"Link": {`</docs>; rel="help"`, `</search>; rel="search"`}}),
+		WithHeaders(http.Header{"Cache-Control": {"no-store"}}),
+	)
+
+	assert.Equal(t, []string{`</docs>; rel="help"`, `</search>; rel="search"`}, err.header.Values("Link"))
+	assert.Equal(t, "no-store", err.header.Get("Cache-Control"))
+}
+
+func TestError_Header_ReturnsCopy(t *testing.T) {
+	err := NewError(http.StatusUnauthorized, "unauthorized", WithAuthenticate("Bearer"))
+
+	h := err.Header()
+	h.Set("WWW-Authenticate", "Basic")
+
+	assert.Equal(t, "Bearer", err.header.Get("WWW-Authenticate"))
+	assert.Nil(t, NewError(http.StatusBadRequest, "bad").Header())
+}
@@ -1,45 +1,48 @@
 package dino
 
 import (
-	"errors"
-	"log/slog"
 	"net/http"
 )
 
 type Handler func(w http.ResponseWriter, r *http.Request) error
 
+// SetErrorFormat changes the format used by every Handler that does not
+// select its own format with Handler.WithErrorFormat. The rest of the
+// configuration of the global ErrorHandler is kept, unless it was not created
+// with NewErrorHandler, in which case it is replaced by a default one
+func SetErrorFormat(format ErrorFormat) {
+	SetErrorHandler(errorHandlerWithFormat(getErrorHandler(), format))
+}
+
 func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	if err := h(w, r); err != nil {
-		handleError(w, err)
+		getErrorHandler().HandleError(w, r, err)
 		return
 	}
 }
 
-func handleError(w http.ResponseWriter, err error) {
-	var httpErr *Error
-
-	// This avoids leaking internal error details to the client. The library user
-	// should wrap errors in dino.Error to provide proper status codes and messages
-	if !errors.As(err, &httpErr) {
-		httpErr = NewError(http.StatusInternalServerError, "Unknown error occurred",
-			WithInternalError(err),
-		)
-	}
-
-	// The only possible error is if the Details field contains non-serializable data
-	if err := WriteJSON(w, httpErr.Code, httpErr); err != nil {
-		failedMsg := "failed to serialize error details"
-
-		httpErr.Details = failedMsg
-
-		slog.Error(failedMsg, "error", err, "original_error", httpErr.err)
-
-		// Since we overwrite Details, we ignore the error here as it will not occur
-		WriteJSON(w, httpErr.Code, httpErr)
+// WithErrorHandler returns a Handler that sends its errors to eh instead of the
+// global ErrorHandler. Errors are handled inside the returned Handler, so
+// middlewares wrapping it will not receive them
+func (h Handler) WithErrorHandler(eh ErrorHandler) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if err := h(w, r); err != nil {
+			eh.HandleError(w, r, err)
+		}
+		return nil
 	}
+}
 
-	if httpErr.log {
-		slog.Error(httpErr.Message, "code", httpErr.Code, "details", httpErr.Details, "error", httpErr.err)
+// WithErrorFormat returns a Handler that renders its own errors in the given
+// format, with the rest of the configuration of the global ErrorHandler at the
+// time of the error, as SetErrorFormat does. Errors are handled inside the
+// returned Handler, so middlewares wrapping it will not receive them
+func (h Handler) WithErrorFormat(format ErrorFormat) Handler {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		if err := h(w, r); err != nil {
+			errorHandlerWithFormat(getErrorHandler(), format).HandleError(w, r, err)
+		}
+		return nil
 	}
 }
 
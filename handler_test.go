@@ -74,3 +74,142 @@ func TestHandler_ServeHTTP(t *testing.T) {
 		})
 	}
 }
+
+func TestHandler_WithErrorFormat(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return NewError(http.StatusNotFound, "not found", WithExtension("id", 42))
+	})
+
+	tests := []struct {
+		name                string
+		format              ErrorFormat
+		expectedContentType string
+		expectedBody        string
+	}{
+		{
+			name:                "json",
+			format:              ErrorFormatJSON,
+			expectedContentType: "application/json",
+			expectedBody:        `{"code":404,"message":"not found"}` + "\n",
+		},
+		{
+			name:                "problem json",
+			format:              ErrorFormatProblemJSON,
+			expectedContentType: "application/problem+json",
+			expectedBody:        `{"type":"about:blank","title":"Not Found","status":404,"detail":"not found","id":42}` + "\n",
+		},
+		{
+			name:                "problem xml",
+			format:              ErrorFormatProblemXML,
+			expectedContentType: "application/problem+xml",
+			expectedBody: `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Not Found</title>` +
+				`<status>404</status><detail>not found</detail><id>42</id></problem>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			handler.WithErrorFormat(tt.format).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}
+
+func TestSetErrorFormat(t *testing.T) {
+	previous := getErrorHandler()
+	defer SetErrorHandler(previous)
+
+	SetErrorFormat(ErrorFormatProblemJSON)
+
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("something went wrong")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"Unknown error occurred"}`, rec.Body.String())
+}
+
+func TestHandler_WithErrorFormat_NonSerializableExtension(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return NewError(http.StatusBadRequest, "test error", WithExtension("ch", make(chan int)))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.WithErrorFormat(ErrorFormatProblemJSON).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.JSONEq(t,
+		`{"type":"about:blank","title":"Bad Request","status":400,"detail":"test error","details":"failed to serialize error details"}`,
+		rec.Body.String(),
+	)
+}
+
+func TestErrorFormat_KeepsErrorHandlerConfig(t *testing.T) {
+	previous := getErrorHandler()
+	defer SetErrorHandler(previous)
+
+	var hooked int
+	SetErrorHandler(NewErrorHandler(
+		WithErrorHook(func(r *http.Request, err *Error) {
+			hooked++
+		}),
+		WithErrorFallback(func(err error) *Error {
+			return NewError(http.StatusBadGateway, "upstream failed")
+		}),
+	))
+
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return errors.New("connection refused")
+	})
+
+	tests := []struct {
+		name                string
+		setup               func() http.Handler
+		expectedContentType string
+	}{
+		{
+			name: "per handler",
+			setup: func() http.Handler {
+				return handler.WithErrorFormat(ErrorFormatProblemJSON)
+			},
+			expectedContentType: "application/problem+json",
+		},
+		{
+			name: "global",
+			setup: func() http.Handler {
+				SetErrorFormat(ErrorFormatProblemXML)
+				return handler
+			},
+			expectedContentType: "application/problem+xml",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hooked = 0
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			tt.setup().ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusBadGateway, rec.Code)
+			assert.Equal(t, tt.expectedContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, 1, hooked)
+		})
+	}
+}
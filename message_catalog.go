@@ -0,0 +1,203 @@
+package dino
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MessageCatalog translates message keys. Lookup returns a fmt format string
+// that is applied to the arguments given together with the key. Formats may
+// use explicit argument indexes (e.g. %[2]s) to reorder or skip arguments.
+//
+// Keys of the messages built into dino and their arguments:
+//
+//	dino.unknown_error                -
+//	dino.client_closed_request        -
+//	dino.request_timeout              -
+//	dino.body_too_large               -
+//	dino.validation_failed            -
+//	dino.body.invalid_json            -
+//	dino.body.invalid_xml             -
+//	dino.body.unreadable              -
+//	dino.body.invalid_form            -
+//	dino.body.unsupported_media_type  -
+//	dino.body.unsupported_encoding    -
+//	dino.body.invalid_encoding        -
+//	dino.body.too_many_files          limit
+//	dino.body.file_too_large          filename, limit
+//	dino.body.values_too_large        limit
+//	dino.body.record_too_large        limit
+//	dino.body.file_type               filename, type
+//	dino.body.file_extension          filename, extension
+//	dino.param.required               name, source
+//	dino.param.int                    name, source
+//	dino.param.int_range              name, source, min, max
+//	dino.param.uint                   name, source
+//	dino.param.float                  name, source
+//	dino.param.bool                   name, source
+//	dino.param.time                   name, source, example
+//	dino.param.duration               name, source
+//	dino.param.invalid                name, source
+//	dino.param.min                    name, source, min
+//	dino.param.max                    name, source, max
+//	dino.param.between                name, source, min, max
+//	dino.param.oneof                  name, source, values
+//	dino.param.pattern                name, source, pattern
+//	dino.param.min_len                name, source, min
+//	dino.param.max_len                name, source, max
+//	dino.param.non_empty              name, source
+//	dino.param.min_count              name, source, min
+//	dino.param.max_count              name, source, max
+//	dino.param.invalid_params         -
+//
+// The messages of the field errors listed in the details of validation errors
+// have their own keys, whose arguments do not include the name and source of
+// the field since it is already part of the field error:
+//
+//	dino.field.required               -
+//	dino.field.int                    -
+//	dino.field.int_range              min, max
+//	dino.field.uint                   -
+//	dino.field.float                  -
+//	dino.field.bool                   -
+//	dino.field.time                   example
+//	dino.field.duration               -
+//	dino.field.invalid                -
+//	dino.field.min                    min
+//	dino.field.max                    max
+//	dino.field.between                min, max
+//	dino.field.oneof                  values
+//	dino.field.pattern                pattern
+//	dino.field.min_len                min
+//	dino.field.max_len                max
+//	dino.field.len                    length
+//	dino.field.non_empty              -
+//	dino.field.min_count              min
+//	dino.field.max_count              max
+//	dino.field.count                  count
+type MessageCatalog interface {
+	Lookup(lang, key string) (format string, ok bool)
+}
+
+// MapCatalog is a MessageCatalog backed by a map of language tag (e.g. "pt" or
+// "pt-BR") to message key to format. Language tags are case-insensitive
+type MapCatalog map[string]map[string]string
+
+func (c MapCatalog) Lookup(lang, key string) (string, bool) {
+	for tag, messages := range c {
+		if strings.EqualFold(tag, lang) {
+			format, ok := messages[key]
+			return format, ok
+		}
+	}
+
+	return "", false
+}
+
+func WithMessageKey(key string, args ...any) ErrorOption {
+	return func(err *Error) {
+		err.key = key
+		err.args = args
+	}
+}
+
+// Localize returns the message of the error translated to the preferred
+// language of an Accept-Language header. It falls back to Message when the
+// error has no key or no accepted language has a translation
+func (e *Error) Localize(c MessageCatalog, acceptLanguage string) string {
+	return localize(c, acceptLanguage, e.key, e.args, e.Message)
+}
+
+// Localize returns the message of the field error translated like
+// Error.Localize. Field errors added with ValidationError.Add and errors of
+// Validate methods that are not field errors have no key and are never
+// translated
+func (fe *FieldError) Localize(c MessageCatalog, acceptLanguage string) string {
+	return localize(c, acceptLanguage, fe.key, fe.args, fe.Message)
+}
+
+// localize returns a copy of v whose field messages are translated, leaving v
+// untouched since it may be shared between requests
+func (v *ValidationError) localize(c MessageCatalog, acceptLanguage string) *ValidationError {
+	localized := &ValidationError{Errors: slices.Clone(v.Errors)}
+	for i := range localized.Errors {
+		localized.Errors[i].Message = localized.Errors[i].Localize(c, acceptLanguage)
+	}
+	return localized
+}
+
+func localize(c MessageCatalog, acceptLanguage, key string, args []any, message string) string {
+	if key == "" || c == nil {
+		return message
+	}
+
+	for _, lang := range parseAcceptLanguage(acceptLanguage) {
+		if format, ok := c.Lookup(lang, key); ok {
+			return fmt.Sprintf(format, args...)
+		}
+
+		// A message for "pt" is also good for "pt-BR"
+		if base, _, found := strings.Cut(lang, "-"); found {
+			if format, ok := c.Lookup(base, key); ok {
+				return fmt.Sprintf(format, args...)
+			}
+		}
+	}
+
+	return message
+}
+
+// parseAcceptLanguage returns the language tags of the header ordered by
+// preference. The wildcard and tags with q=0 are dropped
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+
+	var tags []weightedTag
+
+	for part := range strings.SplitSeq(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for param := range strings.SplitSeq(params, ";") {
+			name, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if name != "q" {
+				continue
+			}
+
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				q = 0
+			} else {
+				q = parsed
+			}
+		}
+
+		if q <= 0 {
+			continue
+		}
+
+		tags = append(tags, weightedTag{tag: tag, q: q})
+	}
+
+	slices.SortStableFunc(tags, func(a, b weightedTag) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	langs := make([]string, len(tags))
+	for i, t := range tags {
+		langs[i] = t.tag
+	}
+
+	return langs
+}
@@ -0,0 +1,207 @@
+package dino
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testCatalog = MapCatalog{
+	"pt": {
+		"dino.unknown_error": "Ocorreu um erro desconhecido",
+		"dino.param.int":     "o parâmetro %[1]q deve ser um número inteiro",
+		"greeting":           "olá, %s",
+
+		"dino.validation_failed": "Falha na validação",
+		"dino.field.int":         "deve ser um número inteiro",
+		"dino.field.required":    "é obrigatório",
+		"dino.field.min_len":     "deve ter pelo menos %d caracteres",
+	},
+	"pt-BR": {
+		"greeting": "oi, %s",
+	},
+	"de": {
+		"dino.param.time": "Parameter %[1]q muss eine Zeit sein, z. B. %[3]s",
+	},
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected []string
+	}{
+		{"empty", "", []string{}},
+		{"single", "pt-BR", []string{"pt-BR"}},
+		{"ordered by q", "en;q=0.5, pt-BR, de;q=0.8", []string{"pt-BR", "de", "en"}},
+		{"stable for equal q", "fr, it", []string{"fr", "it"}},
+		{"drops wildcard and q=0", "*, en;q=0, es;q=0.1", []string{"es"}},
+		{"invalid q", "en;q=abc, fr", []string{"fr"}},
+		{"underscore", "pt_BR", []string{"pt-BR"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, parseAcceptLanguage(tt.header))
+		})
+	}
+}
+
+func TestMapCatalog_Lookup(t *testing.T) {
+	format, ok := testCatalog.Lookup("PT-br", "greeting")
+	assert.True(t, ok)
+	assert.Equal(t, "oi, %s", format)
+
+	_, ok = testCatalog.Lookup("pt-BR", "dino.unknown_error")
+	assert.False(t, ok)
+
+	_, ok = testCatalog.Lookup("fr", "greeting")
+	assert.False(t, ok)
+}
+
+func TestError_Localize(t *testing.T) {
+	err := NewError(http.StatusOK, "hello, john", WithMessageKey("greeting", "john"))
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{"exact tag", "pt-BR", "oi, john"},
+		{"base language", "pt-PT", "olá, john"},
+		{"preferred language without translation", "fr, pt;q=0.5", "olá, john"},
+		{"fallback", "fr", "hello, john"},
+		{"no header", "", "hello, john"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, err.Localize(testCatalog, tt.acceptLanguage))
+		})
+	}
+}
+
+func TestError_Localize_WithoutKey(t *testing.T) {
+	err := NewError(http.StatusNotFound, "not found")
+
+	assert.Equal(t, "not found", err.Localize(testCatalog, "pt"))
+	assert.Equal(t, "not found", err.Localize(nil, "pt"))
+}
+
+func TestError_Localize_ParamErrors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?page=abc&since=yesterday", nil)
+
+	_, err := QueryParam(req, "page").Int()
+	var httpErr *Error
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, `o parâmetro "page" deve ser um número inteiro`, httpErr.Localize(testCatalog, "pt-BR"))
+
+	_, err = QueryParam(req, "since").Time("2006-01-02")
+	assert.ErrorAs(t, err, &httpErr)
+	assert.Regexp(t, `^Parameter "since" muss eine Zeit sein, z. B. \d{4}-\d{2}-\d{2}$`, httpErr.Localize(testCatalog, "de"))
+}
+
+func TestNewErrorHandler_WithErrorCatalog(t *testing.T) {
+	eh := NewErrorHandler(WithErrorCatalog(testCatalog))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Language", "pt-BR,pt;q=0.9,en;q=0.8")
+	rec := httptest.NewRecorder()
+
+	eh.HandleError(rec, req, errors.New("boom"))
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"code":500,"message":"Ocorreu um erro desconhecido"}`, rec.Body.String())
+}
+
+func TestNewErrorHandler_WithErrorCatalog_LogsOriginalMessage(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	eh := NewErrorHandler(WithErrorCatalog(testCatalog), WithErrorLogger(logger))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Accept-Language", "pt")
+	rec := httptest.NewRecorder()
+
+	eh.HandleError(rec, req, errors.New("boom"))
+
+	assert.JSONEq(t, `{"code":500,"message":"Ocorreu um erro desconhecido"}`, rec.Body.String())
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &record))
+	assert.Equal(t, "Unknown error occurred", record["msg"])
+}
+
+type testLocalizedParams struct {
+	Page int    `query:"page"`
+	Sort string `query:"sort,required"`
+}
+
+type testLocalizedBody struct {
+	Name string `json:"name" validate:"min=3"`
+}
+
+func (b testLocalizedBody) Validate() error {
+	var v ValidationError
+	v.Add("nickname", "taken", "is already taken", nil)
+	return v.Err()
+}
+
+func TestNewErrorHandler_WithErrorCatalog_FieldErrors(t *testing.T) {
+	eh := NewErrorHandler(WithErrorCatalog(testCatalog))
+
+	req := httptest.NewRequest(http.MethodGet, "/test?page=abc", nil)
+	req.Header.Set("Accept-Language", "pt")
+
+	_, paramsErr := BindParams[testLocalizedParams](req)
+	require.Error(t, paramsErr)
+
+	bodyErr := Validate(testLocalizedBody{Name: "ab"})
+	require.Error(t, bodyErr)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{
+			name: "params",
+			err:  paramsErr,
+			expected: `{"code":400,"message":"invalid parameters","details":{"errors":[
+				{"field":"page","code":"invalid","message":"deve ser um número inteiro","value":"abc"},
+				{"field":"sort","code":"required","message":"é obrigatório","value":""}
+			]}}`,
+		},
+		{
+			name: "validate",
+			err:  bodyErr,
+			expected: `{"code":422,"message":"Falha na validação","details":{"errors":[
+				{"field":"name","code":"min","message":"deve ter pelo menos 3 caracteres","value":"ab"},
+				{"field":"nickname","code":"taken","message":"is already taken"}
+			]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+
+			eh.HandleError(rec, req, tt.err)
+
+			assert.JSONEq(t, tt.expected, rec.Body.String())
+		})
+	}
+
+	// The errors are shared and must keep their original messages
+	var httpErr *Error
+	require.ErrorAs(t, bodyErr, &httpErr)
+	assert.Equal(t, "must be at least 3 characters long", httpErr.Details.(*ValidationError).Errors[0].Message)
+}
@@ -0,0 +1,25 @@
+package dino
+
+import (
+	"net/http"
+)
+
+// BodyLimitMiddleware limits request bodies to limit bytes. Requests declaring
+// a larger Content-Length are rejected right away and reading past the limit
+// fails with *http.MaxBytesError, which the Read* helpers and the default
+// ErrorRegistry turn into a 413 error
+func BodyLimitMiddleware(limit int64) Middleware {
+	return func(h Handler) Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			if r.ContentLength > limit {
+				return bodyTooLargeError(&http.MaxBytesError{Limit: limit})
+			}
+
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+
+			return h(w, r)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package dino_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willpinha/dino"
+)
+
+type bodyLimitPayload struct {
+	Name string `json:"name"`
+}
+
+func TestBodyLimitMiddleware_WithinLimit(t *testing.T) {
+	handler := dino.BodyLimitMiddleware(64)(func(w http.ResponseWriter, r *http.Request) error {
+		payload, err := dino.ReadJSON[bodyLimitPayload](r.Body)
+		if err != nil {
+			return err
+		}
+		return dino.WriteJSON(w, http.StatusOK, payload)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John"}`))
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"John"}`, rec.Body.String())
+}
+
+func TestBodyLimitMiddleware_ContentLengthOverLimit(t *testing.T) {
+	handlerCalled := false
+
+	handler := dino.BodyLimitMiddleware(4)(func(w http.ResponseWriter, r *http.Request) error {
+		handlerCalled = true
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John"}`))
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	var httpErr *dino.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	assert.Equal(t, map[string]int64{"limit": 4}, httpErr.Details)
+	assert.False(t, handlerCalled)
+}
+
+func TestBodyLimitMiddleware_UnknownContentLength(t *testing.T) {
+	handler := dino.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		_, err := dino.ReadBytes(r.Body)
+		return err
+	}).WithMiddlewares(dino.BodyLimitMiddleware(4))
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John"}`))
+	req.ContentLength = -1
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	assert.JSONEq(t, `{"code":413,"message":"Request body too large","details":{"limit":4}}`, rec.Body.String())
+}
@@ -0,0 +1,28 @@
+package dino
+
+import (
+	"net/http"
+)
+
+// BufferBodyMiddleware buffers request bodies with BufferBody, so middlewares
+// that need the raw body, e.g. to verify a signature, do not prevent the
+// handler from reading it. The spilled bodies are removed once the handler
+// returns
+func BufferBodyMiddleware(opts ...ReadOption) Middleware {
+	return func(h Handler) Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			// The body is released by whoever buffered it
+			if _, ok := r.Body.(*BufferedBody); ok {
+				return h(w, r)
+			}
+
+			b, err := BufferBody(r, opts...)
+			if err != nil {
+				return err
+			}
+			defer b.Release()
+
+			return h(w, r)
+		}
+	}
+}
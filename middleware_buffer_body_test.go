@@ -0,0 +1,151 @@
+package dino_test
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willpinha/dino"
+)
+
+type bufferPayload struct {
+	Name string `json:"name"`
+}
+
+func TestBufferBodyMiddleware(t *testing.T) {
+	var signed []byte
+
+	signature := func(h dino.Handler) dino.Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			data, err := io.ReadAll(r.Body)
+			if err != nil {
+				return err
+			}
+			signed = data
+			return h(w, r)
+		}
+	}
+
+	handler := dino.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		payload, err := dino.ReadJSON[bufferPayload](r.Body)
+		if err != nil {
+			return err
+		}
+		return dino.WriteJSON(w, http.StatusOK, payload)
+	}).WithMiddlewares(dino.BufferBodyMiddleware(), signature)
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John"}`))
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.JSONEq(t, `{"name":"John"}`, rec.Body.String())
+	assert.Equal(t, `{"name":"John"}`, string(signed))
+}
+
+func TestBufferBodyMiddleware_TooLarge(t *testing.T) {
+	handlerCalled := false
+
+	handler := dino.BufferBodyMiddleware(dino.WithMaxBytes(4))(func(w http.ResponseWriter, r *http.Request) error {
+		handlerCalled = true
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John"}`))
+
+	err := handler(httptest.NewRecorder(), req)
+
+	var httpErr *dino.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	assert.False(t, handlerCalled)
+}
+
+func TestBufferBodyMiddleware_Nested(t *testing.T) {
+	payload := strings.Repeat("a", 100)
+
+	handler := dino.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return nil
+	}).WithMiddlewares(
+		func(h dino.Handler) dino.Handler {
+			return func(w http.ResponseWriter, r *http.Request) error {
+				b, err := dino.BufferBody(r, dino.WithMaxMemory(10))
+				if err != nil {
+					return err
+				}
+				defer b.Release()
+				return h(w, r)
+			}
+		},
+		func(h dino.Handler) dino.Handler {
+			return func(w http.ResponseWriter, r *http.Request) error {
+				err := h(w, r)
+
+				// The inner middleware does not release a body it did not buffer
+				data, readErr := dino.ReadBytes(r.Body)
+				require.NoError(t, readErr)
+				assert.Equal(t, payload, string(data))
+
+				return err
+			}
+		},
+		dino.BufferBodyMiddleware(),
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(payload))
+
+	require.NoError(t, handler(httptest.NewRecorder(), req))
+}
+
+type bufferForm struct {
+	Name string `form:"name"`
+	Age  int    `form:"age"`
+}
+
+func TestBufferBodyMiddleware_Form(t *testing.T) {
+	readBody := func(h dino.Handler) dino.Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			if _, err := io.ReadAll(r.Body); err != nil {
+				return err
+			}
+			return h(w, r)
+		}
+	}
+
+	tests := []struct {
+		name string
+		read func(r *http.Request) (bufferForm, error)
+	}{
+		{"Bind", dino.Bind[bufferForm]},
+		{"ReadForm", func(r *http.Request) (bufferForm, error) {
+			return dino.ReadForm[bufferForm](r)
+		}},
+		{"FormParam", func(r *http.Request) (bufferForm, error) {
+			age, err := dino.FormParam(r, "age").Int()
+			return bufferForm{Name: dino.FormParam(r, "name").String(), Age: age}, err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var result bufferForm
+
+			handler := dino.Handler(func(w http.ResponseWriter, r *http.Request) error {
+				var err error
+				result, err = tt.read(r)
+				return err
+			}).WithMiddlewares(dino.BufferBodyMiddleware(), readBody)
+
+			req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("name=John&age=30"))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			require.NoError(t, handler(httptest.NewRecorder(), req))
+			assert.Equal(t, bufferForm{Name: "John", Age: 30}, result)
+		})
+	}
+}
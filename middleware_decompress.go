@@ -0,0 +1,183 @@
+package dino
+
+import (
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// DefaultMaxDecompressedSize is the default limit of DecompressMiddleware for
+// decompressed bodies
+const DefaultMaxDecompressedSize = 10 << 20
+
+// Decompressor returns a reader decompressing r. For example, zstd and brotli
+// can be supported with github.com/klauspost/compress/zstd and
+// github.com/andybalholm/brotli:
+//
+//	dino.WithDecompressor("zstd", func(r io.Reader) (io.ReadCloser, error) {
+//		d, err := zstd.NewReader(r)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return d.IOReadCloser(), nil
+//	})
+//
+//	dino.WithDecompressor("br", func(r io.Reader) (io.ReadCloser, error) {
+//		return io.NopCloser(brotli.NewReader(r)), nil
+//	})
+type Decompressor func(r io.Reader) (io.ReadCloser, error)
+
+type decompressConfig struct {
+	maxSize       int64
+	decompressors map[string]Decompressor
+}
+
+type DecompressOption func(*decompressConfig)
+
+// WithDecompressor registers the Decompressor of a content coding, replacing
+// the built-in one if any
+func WithDecompressor(encoding string, d Decompressor) DecompressOption {
+	return func(config *decompressConfig) {
+		config.decompressors[strings.ToLower(encoding)] = d
+	}
+}
+
+// WithMaxDecompressedSize limits decompressed bodies to n bytes. A value <= 0
+// disables the limit
+func WithMaxDecompressedSize(n int64) DecompressOption {
+	return func(config *decompressConfig) {
+		config.maxSize = n
+	}
+}
+
+// DecompressMiddleware decompresses request bodies according to their
+// Content-Encoding header, supporting gzip and deflate out of the box and
+// other codings with WithDecompressor. Bodies with unknown codings are
+// rejected with a 415 error listing the supported ones in Accept-Encoding.
+//
+// Reading more than the decompressed size limit fails with
+// *http.MaxBytesError, which the Read* helpers and the default ErrorRegistry
+// turn into a 413 error
+func DecompressMiddleware(opts ...DecompressOption) Middleware {
+	config := decompressConfig{
+		maxSize: DefaultMaxDecompressedSize,
+		decompressors: map[string]Decompressor{
+			"gzip":    gzipDecompressor,
+			"x-gzip":  gzipDecompressor,
+			"deflate": zlibDecompressor,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return func(h Handler) Handler {
+		return func(w http.ResponseWriter, r *http.Request) error {
+			encodings := contentEncodings(r.Header)
+			if len(encodings) == 0 || r.Body == nil || r.Body == http.NoBody {
+				return h(w, r)
+			}
+
+			body := &decompressBody{closers: []io.Closer{r.Body}}
+			defer body.Close()
+
+			var reader io.Reader = r.Body
+
+			// Codings are listed in the order they were applied
+			for _, encoding := range slices.Backward(encodings) {
+				d, ok := config.decompressors[encoding]
+				if !ok {
+					return config.unsupportedEncodingError()
+				}
+
+				rc, err := d(reader)
+				if err != nil {
+					return readError(err, NewError(http.StatusBadRequest, "invalid compressed body",
+						WithInternalError(err),
+						WithMessageKey("dino.body.invalid_encoding"),
+					))
+				}
+
+				body.closers = append(body.closers, rc)
+				reader = rc
+			}
+
+			if config.maxSize > 0 {
+				reader = readConfig{maxBytes: config.maxSize}.reader(reader)
+			}
+
+			body.Reader = reader
+
+			r.Body = body
+			r.ContentLength = -1
+			r.Header.Del("Content-Encoding")
+			r.Header.Del("Content-Length")
+
+			return h(w, r)
+		}
+	}
+}
+
+func (config decompressConfig) unsupportedEncodingError() *Error {
+	supported := slices.Sorted(maps.Keys(config.decompressors))
+
+	return NewError(http.StatusUnsupportedMediaType, "Unsupported content encoding",
+		WithDetails(map[string][]string{"supported": supported}),
+		WithHeader("Accept-Encoding", strings.Join(supported, ", ")),
+		WithMessageKey("dino.body.unsupported_encoding"),
+	)
+}
+
+// contentEncodings returns the codings of the Content-Encoding header, without
+// the identity coding
+func contentEncodings(h http.Header) []string {
+	var encodings []string
+
+	for _, value := range h.Values("Content-Encoding") {
+		for encoding := range strings.SplitSeq(value, ",") {
+			encoding = strings.ToLower(strings.TrimSpace(encoding))
+			if encoding != "" && encoding != "identity" {
+				encodings = append(encodings, encoding)
+			}
+		}
+	}
+
+	return encodings
+}
+
+func gzipDecompressor(r io.Reader) (io.ReadCloser, error) {
+	return gzip.NewReader(r)
+}
+
+// The deflate coding is the zlib format (RFC 1950), not raw deflate
+func zlibDecompressor(r io.Reader) (io.ReadCloser, error) {
+	return zlib.NewReader(r)
+}
+
+// decompressBody closes the decompressors and the original body
+type decompressBody struct {
+	io.Reader
+	closers []io.Closer
+	closed  bool
+}
+
+func (b *decompressBody) Close() error {
+	if b.closed {
+		return nil
+	}
+	b.closed = true
+
+	var errs []error
+	for _, c := range slices.Backward(b.closers) {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,162 @@
+package dino_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willpinha/dino"
+)
+
+func gzipBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func zlibBytes(t *testing.T, data []byte) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, err := zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	return buf.Bytes()
+}
+
+func echoBodyHandler(w http.ResponseWriter, r *http.Request) error {
+	data, err := dino.ReadBytes(r.Body)
+	if err != nil {
+		return err
+	}
+	return dino.WriteBytes(w, http.StatusOK, "text/plain", data)
+}
+
+func newEncodedRequest(encoding string, body []byte) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(body))
+	req.Header.Set("Content-Encoding", encoding)
+	return req
+}
+
+func TestDecompressMiddleware(t *testing.T) {
+	payload := []byte(`{"name":"John"}`)
+
+	tests := []struct {
+		name     string
+		encoding string
+		body     []byte
+	}{
+		{"gzip", "gzip", gzipBytes(t, payload)},
+		{"x-gzip", "X-GZIP", gzipBytes(t, payload)},
+		{"deflate", "deflate", zlibBytes(t, payload)},
+		{"identity", "identity", payload},
+		{"stacked", "deflate, gzip", gzipBytes(t, zlibBytes(t, payload))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var contentEncoding string
+
+			handler := dino.DecompressMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+				contentEncoding = r.Header.Get("Content-Encoding")
+				return echoBodyHandler(w, r)
+			})
+
+			rec := httptest.NewRecorder()
+			err := handler(rec, newEncodedRequest(tt.encoding, tt.body))
+
+			require.NoError(t, err)
+			assert.Equal(t, string(payload), rec.Body.String())
+			if tt.encoding != "identity" {
+				assert.Empty(t, contentEncoding)
+			}
+		})
+	}
+}
+
+func TestDecompressMiddleware_NoEncoding(t *testing.T) {
+	handler := dino.DecompressMiddleware()(echoBodyHandler)
+
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader("plain"))
+	rec := httptest.NewRecorder()
+
+	require.NoError(t, handler(rec, req))
+	assert.Equal(t, "plain", rec.Body.String())
+}
+
+func TestDecompressMiddleware_UnsupportedEncoding(t *testing.T) {
+	handlerCalled := false
+
+	handler := dino.DecompressMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+		handlerCalled = true
+		return nil
+	})
+
+	err := handler(httptest.NewRecorder(), newEncodedRequest("br", []byte("data")))
+
+	var httpErr *dino.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnsupportedMediaType, httpErr.Code)
+	assert.Equal(t, "deflate, gzip, x-gzip", httpErr.Header().Get("Accept-Encoding"))
+	assert.Equal(t, map[string][]string{"supported": {"deflate", "gzip", "x-gzip"}}, httpErr.Details)
+	assert.False(t, handlerCalled)
+}
+
+func TestDecompressMiddleware_CustomDecompressor(t *testing.T) {
+	reverse := func(r io.Reader) (io.ReadCloser, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		for i, j := 0, len(data)-1; i < j; i, j = i+1, j-1 {
+			data[i], data[j] = data[j], data[i]
+		}
+		return io.NopCloser(bytes.NewReader(data)), nil
+	}
+
+	handler := dino.DecompressMiddleware(dino.WithDecompressor("Reverse", reverse))(echoBodyHandler)
+
+	rec := httptest.NewRecorder()
+	err := handler(rec, newEncodedRequest("reverse", []byte("olleh")))
+
+	require.NoError(t, err)
+	assert.Equal(t, "hello", rec.Body.String())
+}
+
+func TestDecompressMiddleware_InvalidBody(t *testing.T) {
+	handler := dino.DecompressMiddleware()(echoBodyHandler)
+
+	err := handler(httptest.NewRecorder(), newEncodedRequest("gzip", []byte("not gzip")))
+
+	var httpErr *dino.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestDecompressMiddleware_ZipBomb(t *testing.T) {
+	body := gzipBytes(t, bytes.Repeat([]byte("0"), 1<<20))
+
+	handler := dino.DecompressMiddleware(dino.WithMaxDecompressedSize(1024))(echoBodyHandler)
+
+	err := handler(httptest.NewRecorder(), newEncodedRequest("gzip", body))
+
+	var httpErr *dino.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	assert.Equal(t, map[string]int64{"limit": 1024}, httpErr.Details)
+}
@@ -0,0 +1,114 @@
+package dino
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+)
+
+// PanicError is the internal cause of the errors created by RecoverMiddleware
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+type recoverResponseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (rrw *recoverResponseWriter) WriteHeader(statusCode int) {
+	rrw.wroteHeader = true
+	rrw.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (rrw *recoverResponseWriter) Write(b []byte) (int, error) {
+	rrw.wroteHeader = true
+	return rrw.ResponseWriter.Write(b)
+}
+
+func (rrw *recoverResponseWriter) Unwrap() http.ResponseWriter {
+	return rrw.ResponseWriter
+}
+
+type recoverConfig struct {
+	logger *slog.Logger
+}
+
+func newRecoverConfig(opts ...RecoverOption) recoverConfig {
+	var options recoverConfig
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+type RecoverOption func(*recoverConfig)
+
+// WithRecoverLogger sets the logger used for panics that happen after the
+// response was written. By default slog.Default() is used
+func WithRecoverLogger(logger *slog.Logger) RecoverOption {
+	return func(options *recoverConfig) {
+		options.logger = logger
+	}
+}
+
+// RecoverMiddleware converts panics into 500 errors whose internal cause is a
+// *PanicError, so they are rendered and logged by the ErrorHandler. If the
+// response was already written, the panic is logged and no error is returned.
+// Panics with http.ErrAbortHandler are propagated to abort the response
+func RecoverMiddleware(opts ...RecoverOption) Middleware {
+	options := newRecoverConfig(opts...)
+
+	return func(h Handler) Handler {
+		return func(w http.ResponseWriter, r *http.Request) (err error) {
+			rrw := &recoverResponseWriter{ResponseWriter: w}
+
+			defer func() {
+				v := recover()
+				if v == nil {
+					return
+				}
+
+				if v == http.ErrAbortHandler {
+					panic(v)
+				}
+
+				panicErr := &PanicError{Value: v, Stack: debug.Stack()}
+				httpErr := unknownError(panicErr, WithStack())
+
+				if !rrw.wroteHeader {
+					err = httpErr
+					return
+				}
+
+				logger := options.logger
+				if logger == nil {
+					logger = slog.Default()
+				}
+
+				logger.LogAttrs(r.Context(), slog.LevelError, "panic recovered after the response was written",
+					slog.Any("error", panicErr),
+					stackAttr(httpErr),
+				)
+
+				err = nil
+			}()
+
+			return h(rrw, r)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package dino_test
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/willpinha/dino"
+)
+
+func TestRecoverMiddleware_NoPanic(t *testing.T) {
+	handler := dino.RecoverMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusCreated)
+		return nil
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, rec.Code)
+}
+
+func TestRecoverMiddleware_ErrorPropagation(t *testing.T) {
+	expectedErr := errors.New("handler error")
+
+	handler := dino.RecoverMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+		return expectedErr
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	assert.ErrorIs(t, err, expectedErr)
+}
+
+func TestRecoverMiddleware_Panic(t *testing.T) {
+	handler := dino.RecoverMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+		panic("something bad happened")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	var httpErr *dino.Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusInternalServerError, httpErr.Code)
+	assert.Equal(t, "Unknown error occurred", httpErr.Message)
+
+	var panicErr *dino.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Equal(t, "something bad happened", panicErr.Value)
+	assert.Contains(t, string(panicErr.Stack), "TestRecoverMiddleware_Panic")
+
+	stack := httpErr.StackTrace()
+	require.NotEmpty(t, stack)
+	assert.True(t, strings.HasPrefix(stack[0].Function, "github.com/willpinha/dino_test.TestRecoverMiddleware_Panic"))
+}
+
+func TestRecoverMiddleware_PanicWithError(t *testing.T) {
+	panicValue := errors.New("boom")
+
+	handler := dino.RecoverMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+		panic(panicValue)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	assert.ErrorIs(t, err, panicValue)
+}
+
+func TestRecoverMiddleware_RendersError(t *testing.T) {
+	handler := dino.Handler(func(w http.ResponseWriter, r *http.Request) error {
+		var m map[string]int
+		m["nil map"] = 1
+		return nil
+	}).WithMiddlewares(dino.RecoverMiddleware())
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.JSONEq(t, `{"code":500,"message":"Unknown error occurred"}`, rec.Body.String())
+}
+
+func TestRecoverMiddleware_PanicAfterResponseWritten(t *testing.T) {
+	mockHandler := &mockLogHandler{}
+	logger := slog.New(mockHandler)
+
+	handler := dino.RecoverMiddleware(dino.WithRecoverLogger(logger))(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("partial"))
+		panic("too late")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+
+	require.Len(t, mockHandler.records, 1)
+	record := mockHandler.records[0]
+	assert.Equal(t, slog.LevelError, record.level)
+	assert.Equal(t, "panic recovered after the response was written", record.message)
+	assert.Contains(t, record.attrs["error"].(error).Error(), "too late")
+	assert.Contains(t, record.attrs, "stack.function")
+}
+
+func TestRecoverMiddleware_ErrAbortHandler(t *testing.T) {
+	handler := dino.RecoverMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+		panic(http.ErrAbortHandler)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler(rec, req)
+	})
+}
+
+func TestRecoverMiddleware_ResponseController(t *testing.T) {
+	handler := dino.RecoverMiddleware()(func(w http.ResponseWriter, r *http.Request) error {
+		return http.NewResponseController(w).Flush()
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	err := handler(rec, req)
+
+	assert.NoError(t, err)
+	assert.True(t, rec.Flushed)
+}
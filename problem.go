@@ -0,0 +1,282 @@
+package dino
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	ProblemTypeBlank = "about:blank"
+	ProblemNamespace = "urn:ietf:rfc:7807"
+)
+
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// ProblemDetails is an RFC 9457 problem document. Extension members are
+// rendered next to the standard members and can never override them
+type ProblemDetails struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+}
+
+func (e *Error) Problem() ProblemDetails {
+	p := ProblemDetails{
+		Type:     e.Type,
+		Title:    e.Title,
+		Status:   e.Code,
+		Detail:   e.Message,
+		Instance: e.Instance,
+	}
+
+	if p.Type == "" {
+		p.Type = ProblemTypeBlank
+	}
+
+	if p.Title == "" {
+		p.Title = http.StatusText(e.Code)
+	}
+
+	if len(e.Extensions) > 0 || e.Details != nil {
+		p.Extensions = make(map[string]any, len(e.Extensions)+1)
+
+		// Validation errors use the "errors" member commonly used with
+		// Problem Details instead of the generic "details" member
+		if v, ok := e.Details.(*ValidationError); ok {
+			p.Extensions["errors"] = v.Errors
+		} else if e.Details != nil {
+			p.Extensions["details"] = e.Details
+		}
+
+		for k, v := range e.Extensions {
+			p.Extensions[k] = v
+		}
+	}
+
+	return p
+}
+
+func (p ProblemDetails) extensionKeys() []string {
+	keys := make([]string, 0, len(p.Extensions))
+
+	for k := range p.Extensions {
+		if !slices.Contains(problemMembers, k) {
+			keys = append(keys, k)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func (p ProblemDetails) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+
+	writeMember := func(key string, value any) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("problem member %q: %w", key, err)
+		}
+
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+
+		buf.WriteString(strconv.Quote(key))
+		buf.WriteByte(':')
+		buf.Write(data)
+
+		return nil
+	}
+
+	buf.WriteByte('{')
+
+	members := []struct {
+		key   string
+		value any
+		empty bool
+	}{
+		{"type", p.Type, p.Type == ""},
+		{"title", p.Title, p.Title == ""},
+		{"status", p.Status, p.Status == 0},
+		{"detail", p.Detail, p.Detail == ""},
+		{"instance", p.Instance, p.Instance == ""},
+	}
+
+	for _, m := range members {
+		if m.empty {
+			continue
+		}
+
+		if err := writeMember(m.key, m.value); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, k := range p.extensionKeys() {
+		if err := writeMember(k, p.Extensions[k]); err != nil {
+			return nil, err
+		}
+	}
+
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+func (p ProblemDetails) MarshalXML(e *xml.Encoder, _ xml.StartElement) error {
+	start := xml.StartElement{Name: xml.Name{Space: ProblemNamespace, Local: "problem"}}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	members := []struct {
+		key   string
+		value string
+	}{
+		{"type", p.Type},
+		{"title", p.Title},
+		{"status", ""},
+		{"detail", p.Detail},
+		{"instance", p.Instance},
+	}
+
+	if p.Status != 0 {
+		members[2].value = strconv.Itoa(p.Status)
+	}
+
+	for _, m := range members {
+		if m.value == "" {
+			continue
+		}
+
+		if err := e.EncodeElement(m.value, xml.StartElement{Name: xml.Name{Local: m.key}}); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range p.extensionKeys() {
+		if err := encodeProblemXMLValue(e, k, p.Extensions[k]); err != nil {
+			return fmt.Errorf("problem member %q: %w", k, err)
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// Arrays and objects follow the XML representation described in RFC 9457
+// Appendix B: array items are wrapped in <i> elements and object members
+// become child elements
+func encodeProblemXMLValue(e *xml.Encoder, name string, value any) error {
+	if value == nil {
+		return nil
+	}
+
+	// encoding/xml writes element names as is, so invalid names would produce
+	// a malformed document
+	if !isXMLName(name) {
+		return fmt.Errorf("invalid XML element name %q", name)
+	}
+
+	if _, ok := value.(xml.Marshaler); ok {
+		return e.EncodeElement(value, xml.StartElement{Name: xml.Name{Local: name}})
+	}
+
+	rv := reflect.ValueOf(value)
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	start := xml.StartElement{Name: xml.Name{Local: name}}
+
+	switch {
+	case (rv.Kind() == reflect.Slice || rv.Kind() == reflect.Array) && rv.Type().Elem().Kind() != reflect.Uint8:
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+
+		for i := range rv.Len() {
+			if err := encodeProblemXMLValue(e, "i", rv.Index(i).Interface()); err != nil {
+				return err
+			}
+		}
+
+		return e.EncodeToken(start.End())
+
+	case rv.Kind() == reflect.Map && rv.Type().Key().Kind() == reflect.String:
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+
+		keys := make([]string, 0, rv.Len())
+		for _, k := range rv.MapKeys() {
+			keys = append(keys, k.String())
+		}
+		slices.Sort(keys)
+
+		for _, k := range keys {
+			v := rv.MapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()))
+			if err := encodeProblemXMLValue(e, k, v.Interface()); err != nil {
+				return err
+			}
+		}
+
+		return e.EncodeToken(start.End())
+	}
+
+	return e.EncodeElement(rv.Interface(), start)
+}
+
+// isXMLName reports whether name is a valid XML element name without a
+// namespace prefix
+func isXMLName(name string) bool {
+	if name == "" || strings.HasPrefix(strings.ToLower(name), "xml") {
+		return false
+	}
+
+	for i, r := range name {
+		switch {
+		case unicode.IsLetter(r) || r == '_':
+		case i > 0 && (unicode.IsDigit(r) || r == '-' || r == '.'):
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func WriteProblemJSON(w http.ResponseWriter, p ProblemDetails) error {
+	// Marshal before writing the header so a failure leaves the response untouched
+	data, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return WriteBytes(w, p.Status, "application/problem+json", append(data, '\n'))
+}
+
+func WriteProblemXML(w http.ResponseWriter, p ProblemDetails) error {
+	data, err := xml.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return WriteBytes(w, p.Status, "application/problem+xml", data)
+}
@@ -0,0 +1,166 @@
+package dino
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestError_Problem_Defaults(t *testing.T) {
+	p := NewError(http.StatusNotFound, "user 42 not found").Problem()
+
+	assert.Equal(t, ProblemTypeBlank, p.Type)
+	assert.Equal(t, "Not Found", p.Title)
+	assert.Equal(t, http.StatusNotFound, p.Status)
+	assert.Equal(t, "user 42 not found", p.Detail)
+	assert.Empty(t, p.Instance)
+	assert.Nil(t, p.Extensions)
+}
+
+func TestError_Problem_AllMembers(t *testing.T) {
+	p := NewError(
+		http.StatusForbidden,
+		"Your current balance is 30, but that costs 50",
+		WithType("https://example.com/probs/out-of-credit"),
+		WithTitle("You do not have enough credit"),
+		WithInstance("/account/12345/msgs/abc"),
+		WithDetails("extra"),
+		WithExtension("balance", 30),
+	).Problem()
+
+	assert.Equal(t, "https://example.com/probs/out-of-credit", p.Type)
+	assert.Equal(t, "You do not have enough credit", p.Title)
+	assert.Equal(t, "/account/12345/msgs/abc", p.Instance)
+	assert.Equal(t, map[string]any{"balance": 30, "details": "extra"}, p.Extensions)
+}
+
+func TestProblemDetails_MarshalJSON(t *testing.T) {
+	p := ProblemDetails{
+		Type:     "https://example.com/probs/out-of-credit",
+		Title:    "You do not have enough credit",
+		Status:   http.StatusForbidden,
+		Detail:   "Your current balance is 30, but that costs 50",
+		Instance: "/account/12345/msgs/abc",
+		Extensions: map[string]any{
+			"balance":  30,
+			"accounts": []string{"/account/12345", "/account/67890"},
+			// Extensions can never override standard members
+			"status": 200,
+		},
+	}
+
+	data, err := p.MarshalJSON()
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"type":"https://example.com/probs/out-of-credit","title":"You do not have enough credit","status":403,`+
+			`"detail":"Your current balance is 30, but that costs 50","instance":"/account/12345/msgs/abc",`+
+			`"accounts":["/account/12345","/account/67890"],"balance":30}`,
+		string(data),
+	)
+}
+
+func TestProblemDetails_MarshalJSON_OmitsEmptyMembers(t *testing.T) {
+	data, err := ProblemDetails{Status: http.StatusBadRequest}.MarshalJSON()
+
+	require.NoError(t, err)
+	assert.Equal(t, `{"status":400}`, string(data))
+}
+
+func TestProblemDetails_MarshalJSON_NonSerializableExtension(t *testing.T) {
+	_, err := ProblemDetails{Extensions: map[string]any{"ch": make(chan int)}}.MarshalJSON()
+
+	require.Error(t, err)
+}
+
+func TestProblemDetails_MarshalXML(t *testing.T) {
+	p := ProblemDetails{
+		Type:   "https://example.com/probs/out-of-credit",
+		Title:  "You do not have enough credit",
+		Status: http.StatusForbidden,
+		Extensions: map[string]any{
+			"balance":  30,
+			"accounts": []string{"/account/12345", "/account/67890"},
+			"limits":   map[string]int{"max": 50},
+		},
+	}
+
+	data, err := xml.Marshal(p)
+
+	require.NoError(t, err)
+	assert.Equal(t,
+		`<problem xmlns="urn:ietf:rfc:7807">`+
+			`<type>https://example.com/probs/out-of-credit</type>`+
+			`<title>You do not have enough credit</title>`+
+			`<status>403</status>`+
+			`<accounts><i>/account/12345</i><i>/account/67890</i></accounts>`+
+			`<balance>30</balance>`+
+			`<limits><max>50</max></limits>`+
+			`</problem>`,
+		string(data),
+	)
+}
+
+func TestProblemDetails_MarshalXML_InvalidNames(t *testing.T) {
+	tests := []struct {
+		name       string
+		extensions map[string]any
+	}{
+		{"extension with space", map[string]any{"bad key": 1}},
+		{"extension starting with digit", map[string]any{"1st": 1}},
+		{"map key with brackets", map[string]any{"details": map[string]string{"items[0]": "x"}}},
+		{"map key with slash", map[string]any{"details": map[string]any{"a/b": 1}}},
+		{"reserved prefix", map[string]any{"xmlns": "x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := xml.Marshal(ProblemDetails{Status: http.StatusBadRequest, Extensions: tt.extensions})
+
+			require.Error(t, err)
+		})
+	}
+
+	_, err := xml.Marshal(ProblemDetails{Extensions: map[string]any{"trace_id": "a", "retry-after.seconds": 1, "ação": true}})
+	require.NoError(t, err)
+}
+
+func TestWriteProblemJSON(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := WriteProblemJSON(rec, NewError(http.StatusConflict, "already exists").Problem())
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+	assert.JSONEq(t, `{"type":"about:blank","title":"Conflict","status":409,"detail":"already exists"}`, rec.Body.String())
+}
+
+func TestWriteProblemJSON_NonSerializable(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	p := NewError(http.StatusBadRequest, "bad", WithDetails(make(chan int))).Problem()
+	err := WriteProblemJSON(rec, p)
+
+	require.Error(t, err)
+	assert.Empty(t, rec.Body.String())
+	assert.Empty(t, rec.Header().Get("Content-Type"))
+}
+
+func TestWriteProblemXML(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	err := WriteProblemXML(rec, NewError(http.StatusConflict, "already exists").Problem())
+
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, "application/problem+xml", rec.Header().Get("Content-Type"))
+	assert.Equal(t,
+		`<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Conflict</title><status>409</status><detail>already exists</detail></problem>`,
+		rec.Body.String(),
+	)
+}
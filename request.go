@@ -1,37 +1,242 @@
 package dino
 
 import (
-	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"io"
 	"net/http"
+	"sync/atomic"
 )
 
-func ReadJSON[T any](r io.Reader) (T, error) {
+type readConfig struct {
+	maxBytes              int64
+	disallowUnknownFields bool
+	rejectTrailingData    bool
+	useNumber             bool
+	caseSensitive         bool
+	skipValidation        bool
+
+	// Multipart options
+	maxFileSize       int64
+	maxValueBytes     int64
+	maxFiles          int
+	allowedTypes      []string
+	allowedExtensions []string
+	tempDir           string
+
+	// Buffering options
+	maxMemory int64
+
+	// Streaming options
+	maxRecordSize int64
+}
+
+var defaultReadOptions atomic.Pointer[[]ReadOption]
+
+// SetDefaultReadOptions sets the options applied to every Read* call before
+// the options given to the call itself
+func SetDefaultReadOptions(opts ...ReadOption) {
+	defaultReadOptions.Store(&opts)
+}
+
+func newReadConfig(opts ...ReadOption) readConfig {
+	var options readConfig
+	if defaults := defaultReadOptions.Load(); defaults != nil {
+		for _, opt := range *defaults {
+			opt(&options)
+		}
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+type ReadOption func(*readConfig)
+
+// WithMaxBytes limits the body to n bytes. Larger bodies result in a 413 error
+func WithMaxBytes(n int64) ReadOption {
+	return func(options *readConfig) {
+		options.maxBytes = n
+	}
+}
+
+// WithDisallowUnknownFields rejects JSON objects with keys that do not match
+// any field of the destination struct. Passing false accepts them again, e.g.
+// to override SetDefaultReadOptions for a single call
+func WithDisallowUnknownFields(disallow bool) ReadOption {
+	return func(options *readConfig) {
+		options.disallowUnknownFields = disallow
+	}
+}
+
+// WithRejectTrailingData rejects JSON bodies with data after the first value
+func WithRejectTrailingData(reject bool) ReadOption {
+	return func(options *readConfig) {
+		options.rejectTrailingData = reject
+	}
+}
+
+// WithUseNumber decodes JSON numbers into interface values as json.Number
+// instead of float64
+func WithUseNumber(use bool) ReadOption {
+	return func(options *readConfig) {
+		options.useNumber = use
+	}
+}
+
+// WithCaseSensitive matches JSON object keys to struct fields
+// case-sensitively, while encoding/json ignores case by default. Keys that
+// only match when ignoring case are unknown fields: they are ignored, or
+// rejected with WithDisallowUnknownFields
+func WithCaseSensitive(sensitive bool) ReadOption {
+	return func(options *readConfig) {
+		options.caseSensitive = sensitive
+	}
+}
+
+// WithoutValidation skips Validate after decoding the body
+func WithoutValidation() ReadOption {
+	return func(options *readConfig) {
+		options.skipValidation = true
+	}
+}
+
+func (options readConfig) validate(v any) error {
+	return options.validateAt(v, "")
+}
+
+// validateAt reports the field errors relative to path
+func (options readConfig) validateAt(v any, path string) error {
+	if options.skipValidation {
+		return nil
+	}
+	return validateAt(v, path)
+}
+
+func (options readConfig) reader(r io.Reader) io.Reader {
+	// Buffered bodies are read from the start, however much was read before
+	if b, ok := r.(*BufferedBody); ok {
+		r = b.Reader()
+	}
+
+	if options.maxBytes <= 0 {
+		return r
+	}
+	return &maxBytesReader{r: r, limit: options.maxBytes, remaining: options.maxBytes}
+}
+
+// maxBytesReader is like http.MaxBytesReader for a plain io.Reader
+type maxBytesReader struct {
+	r         io.Reader
+	limit     int64
+	remaining int64
+	err       error
+}
+
+func (mbr *maxBytesReader) Read(p []byte) (int, error) {
+	if mbr.err != nil {
+		return 0, mbr.err
+	}
+
+	// Read one byte more than allowed to detect bodies over the limit
+	if int64(len(p)) > mbr.remaining+1 {
+		p = p[:mbr.remaining+1]
+	}
+
+	n, err := mbr.r.Read(p)
+
+	if int64(n) <= mbr.remaining {
+		mbr.remaining -= int64(n)
+		mbr.err = err
+		return n, err
+	}
+
+	n = int(mbr.remaining)
+	mbr.remaining = 0
+	mbr.err = &http.MaxBytesError{Limit: mbr.limit}
+
+	return n, mbr.err
+}
+
+func bodyTooLargeError(err *http.MaxBytesError) *Error {
+	return NewError(http.StatusRequestEntityTooLarge, "Request body too large",
+		WithDetails(map[string]int64{"limit": err.Limit}),
+		WithInternalError(err),
+		WithMessageKey("dino.body_too_large"),
+	)
+}
+
+// readError turns errors caused by a body over the limit into a 413 and any
+// other error into the given error
+func readError(err error, otherwise *Error) *Error {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		return bodyTooLargeError(maxBytesErr)
+	}
+	return otherwise
+}
+
+// ReadJSON decodes r into a T and validates it with Validate, unless the
+// WithoutValidation option is given
+func ReadJSON[T any](r io.Reader, opts ...ReadOption) (T, error) {
 	var v T
 
-	if err := json.NewDecoder(r).Decode(&v); err != nil {
-		return v, NewError(http.StatusBadRequest, "invalid JSON body", WithDetails(err))
+	options := newReadConfig(opts...)
+
+	if err := decodeJSON(r, &v, options); err != nil {
+		return v, err
+	}
+
+	if err := options.validate(&v); err != nil {
+		return v, err
 	}
 
 	return v, nil
 }
 
-func ReadXML[T any](r io.Reader) (T, error) {
+// ReadXML is like ReadJSON for XML bodies
+func ReadXML[T any](r io.Reader, opts ...ReadOption) (T, error) {
 	var v T
 
-	if err := xml.NewDecoder(r).Decode(&v); err != nil {
-		return v, NewError(http.StatusBadRequest, "invalid XML body", WithDetails(err))
+	options := newReadConfig(opts...)
+
+	if err := decodeXML(r, &v, options); err != nil {
+		return v, err
+	}
+
+	if err := options.validate(&v); err != nil {
+		return v, err
 	}
 
 	return v, nil
 }
 
-func ReadBytes(r io.Reader) ([]byte, error) {
-	data, err := io.ReadAll(r)
+func decodeXML(r io.Reader, v any, options readConfig) error {
+	if err := xml.NewDecoder(options.reader(r)).Decode(v); err != nil {
+		return readError(err, NewError(http.StatusBadRequest, "invalid XML body",
+			WithDetails(err),
+			WithMessageKey("dino.body.invalid_xml"),
+		))
+	}
+
+	return nil
+}
+
+func ReadBytes(r io.Reader, opts ...ReadOption) ([]byte, error) {
+	options := newReadConfig(opts...)
+
+	data, err := io.ReadAll(options.reader(r))
 	if err != nil {
-		return nil, NewError(http.StatusBadRequest, "unable to read body", WithDetails(err))
+		return nil, unreadableBodyError(err)
 	}
 
 	return data, nil
 }
+
+func unreadableBodyError(err error) *Error {
+	return readError(err, NewError(http.StatusBadRequest, "unable to read body",
+		WithDetails(err),
+		WithMessageKey("dino.body.unreadable"),
+	))
+}
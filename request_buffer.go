@@ -0,0 +1,176 @@
+package dino
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+)
+
+// DefaultMaxMemory is the default number of bytes of a body kept in memory by
+// BufferBody
+const DefaultMaxMemory = 1 << 20
+
+// WithMaxMemory sets the number of bytes of a body kept in memory by
+// BufferBody. Larger bodies are spilled to a temporary file in the directory
+// set with WithTempDir
+func WithMaxMemory(n int64) ReadOption {
+	return func(options *readConfig) {
+		options.maxMemory = n
+	}
+}
+
+// BufferedBody is a request body read once and kept in memory, or on disk when
+// it is larger than the memory limit, so it can be read any number of times.
+// The Read* helpers always read it from the start, while Read continues where
+// the previous call stopped, as with any other body
+type BufferedBody struct {
+	data []byte
+	file *os.File
+	size int64
+	r    io.Reader
+}
+
+// BufferBody reads the body of r and replaces it with a BufferedBody, which is
+// returned as is if the body was already buffered. WithMaxBytes limits the
+// body, WithMaxMemory the part kept in memory and WithTempDir sets the
+// directory of the file the rest is spilled to. The caller is responsible for
+// calling Release, which BufferBodyMiddleware does
+func BufferBody(r *http.Request, opts ...ReadOption) (*BufferedBody, error) {
+	if b, ok := r.Body.(*BufferedBody); ok {
+		return b, nil
+	}
+
+	b := &BufferedBody{}
+
+	if r.Body != nil && r.Body != http.NoBody {
+		if err := b.fill(r.Body, newReadConfig(opts...)); err != nil {
+			return nil, err
+		}
+	}
+
+	r.Body = b
+	r.ContentLength = b.size
+	r.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(b.Reader()), nil
+	}
+
+	return b, nil
+}
+
+func (b *BufferedBody) fill(body io.Reader, options readConfig) error {
+	maxMemory := options.maxMemory
+	if maxMemory <= 0 {
+		maxMemory = DefaultMaxMemory
+	}
+
+	body = options.reader(body)
+
+	// Read one byte more than the memory limit to know if the body fits
+	var buf bytes.Buffer
+	if _, err := io.CopyN(&buf, body, maxMemory+1); err != nil && err != io.EOF {
+		return unreadableBodyError(err)
+	}
+
+	if int64(buf.Len()) <= maxMemory {
+		b.data = buf.Bytes()
+		b.size = int64(buf.Len())
+		return nil
+	}
+
+	f, err := os.CreateTemp(options.tempDir, "dino-body-*")
+	if err != nil {
+		return fmt.Errorf("dino: buffering body: %w", err)
+	}
+
+	b.file = f
+
+	size, err := buf.WriteTo(f)
+	if err != nil {
+		b.Release()
+		return fmt.Errorf("dino: buffering body: %w", err)
+	}
+
+	// Read errors are client errors, while write errors are server errors
+	tr := &trackingReader{r: body}
+
+	n, err := io.Copy(f, tr)
+	if err != nil {
+		b.Release()
+		if tr.err != nil {
+			return unreadableBodyError(tr.err)
+		}
+		return fmt.Errorf("dino: buffering body: %w", err)
+	}
+
+	b.size = size + n
+
+	return nil
+}
+
+type trackingReader struct {
+	r   io.Reader
+	err error
+}
+
+func (tr *trackingReader) Read(p []byte) (int, error) {
+	n, err := tr.r.Read(p)
+	if err != nil && err != io.EOF {
+		tr.err = err
+	}
+	return n, err
+}
+
+// Size returns the size of the body in bytes
+func (b *BufferedBody) Size() int64 {
+	return b.size
+}
+
+// Reader returns a new reader of the whole body, independent of Read and of
+// other readers
+func (b *BufferedBody) Reader() io.Reader {
+	if b.file != nil {
+		return io.NewSectionReader(b.file, 0, b.size)
+	}
+	return bytes.NewReader(b.data)
+}
+
+// Bytes returns the whole body, which must not be modified. Bodies spilled to
+// disk are read from their file
+func (b *BufferedBody) Bytes() ([]byte, error) {
+	if b.file != nil {
+		return io.ReadAll(b.Reader())
+	}
+	return b.data, nil
+}
+
+func (b *BufferedBody) Read(p []byte) (int, error) {
+	if b.r == nil {
+		b.r = b.Reader()
+	}
+	return b.r.Read(p)
+}
+
+// Close does nothing, so the body can still be read by others after being
+// closed. Use Release to free the body
+func (b *BufferedBody) Close() error {
+	return nil
+}
+
+// Release removes the file of a body spilled to disk, which cannot be read
+// afterwards. It does nothing for bodies kept in memory
+func (b *BufferedBody) Release() error {
+	if b.file == nil {
+		return nil
+	}
+
+	f := b.file
+	b.file = nil
+	b.size = 0
+	b.r = nil
+
+	f.Close()
+
+	return os.Remove(f.Name())
+}
@@ -0,0 +1,114 @@
+package dino
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBufferBody_InMemory(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John"}`))
+
+	b, err := BufferBody(req)
+	require.NoError(t, err)
+	assert.Nil(t, b.file)
+	assert.Equal(t, int64(15), b.Size())
+	assert.Equal(t, int64(15), req.ContentLength)
+
+	data, err := b.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"John"}`, string(data))
+
+	// Reading the body does not prevent the Read* helpers from reading it
+	_, err = io.ReadAll(req.Body)
+	require.NoError(t, err)
+
+	for range 2 {
+		result, err := ReadJSON[testBindStruct](req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "John", result.Name)
+	}
+
+	again, err := BufferBody(req)
+	require.NoError(t, err)
+	assert.Same(t, b, again)
+
+	getBody, err := req.GetBody()
+	require.NoError(t, err)
+	data, err = io.ReadAll(getBody)
+	require.NoError(t, err)
+	assert.Equal(t, `{"name":"John"}`, string(data))
+}
+
+func TestBufferBody_SpillsToDisk(t *testing.T) {
+	dir := t.TempDir()
+	payload := bytes.Repeat([]byte("abcdefgh"), 100)
+
+	req := httptest.NewRequest(http.MethodPost, "/test", bytes.NewReader(payload))
+
+	b, err := BufferBody(req, WithMaxMemory(64), WithTempDir(dir))
+	require.NoError(t, err)
+	require.NotNil(t, b.file)
+	assert.Equal(t, int64(len(payload)), b.Size())
+
+	data, err := b.Bytes()
+	require.NoError(t, err)
+	assert.Equal(t, payload, data)
+
+	data, err = ReadBytes(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, payload, data)
+
+	require.NoError(t, b.Release())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestBufferBody_TooLarge(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, maxMemory := range []int64{1024, 8} {
+		req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(strings.Repeat("a", 100)))
+
+		_, err := BufferBody(req, WithMaxBytes(50), WithMaxMemory(maxMemory), WithTempDir(dir))
+
+		var httpErr *Error
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	}
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "spilled bodies are removed on error")
+}
+
+func TestBufferBody_ReadError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	req.Body = io.NopCloser(&errorReader{})
+
+	_, err := BufferBody(req)
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
+
+func TestBufferBody_NoBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+
+	b, err := BufferBody(req)
+	require.NoError(t, err)
+
+	data, err := b.Bytes()
+	require.NoError(t, err)
+	assert.Empty(t, data)
+}
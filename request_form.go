@@ -0,0 +1,427 @@
+package dino
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// sniffLen is the number of bytes used by http.DetectContentType
+const sniffLen = 512
+
+var formMediaTypes = []string{"application/x-www-form-urlencoded", "multipart/form-data"}
+
+// WithMaxFileSize limits each file of a multipart body to n bytes. Larger
+// files result in a 413 error
+func WithMaxFileSize(n int64) ReadOption {
+	return func(options *readConfig) {
+		options.maxFileSize = n
+	}
+}
+
+// WithMaxFiles limits the number of files of a multipart body. Bodies with
+// more files result in a 413 error
+func WithMaxFiles(n int) ReadOption {
+	return func(options *readConfig) {
+		options.maxFiles = n
+	}
+}
+
+// DefaultMaxValueBytes is the default total size of the values of a multipart
+// body read by ReadMultipart, like the budget of mime/multipart
+const DefaultMaxValueBytes = 10 << 20
+
+// WithMaxValueBytes limits the total size of the values of a multipart body,
+// i.e. the parts that are not files, read by ReadMultipart. Larger values
+// result in a 413 error
+func WithMaxValueBytes(n int64) ReadOption {
+	return func(options *readConfig) {
+		options.maxValueBytes = n
+	}
+}
+
+// WithAllowedTypes rejects files whose content type, detected from their
+// content with http.DetectContentType, is not one of types. Types may use a
+// wildcard subtype such as "image/*"
+func WithAllowedTypes(types ...string) ReadOption {
+	return func(options *readConfig) {
+		options.allowedTypes = types
+	}
+}
+
+// WithAllowedExtensions rejects files whose name does not end with one of
+// extensions, e.g. ".png". Extensions are case-insensitive
+func WithAllowedExtensions(extensions ...string) ReadOption {
+	return func(options *readConfig) {
+		options.allowedExtensions = extensions
+	}
+}
+
+// WithTempDir sets the directory of the temporary files created by
+// Part.Spool and BufferBody. It defaults to os.TempDir
+func WithTempDir(dir string) ReadOption {
+	return func(options *readConfig) {
+		options.tempDir = dir
+	}
+}
+
+// ReadForm binds the values of an application/x-www-form-urlencoded or
+// multipart/form-data body into a T like Bind does and validates it with
+// Validate. Files of multipart bodies are not bound, use NewMultipartReader or
+// ReadMultipart to receive them
+func ReadForm[T any](r *http.Request, opts ...ReadOption) (T, error) {
+	var v T
+
+	options := newReadConfig(opts...)
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if !slices.Contains(formMediaTypes, mediaType) {
+		return v, unsupportedMediaTypeError(formMediaTypes)
+	}
+
+	var err error
+	if mediaType == "multipart/form-data" {
+		err = decodeMultipart(r, &v, options)
+	} else {
+		err = decodeForm(r, &v, options)
+	}
+
+	if err != nil {
+		return v, err
+	}
+
+	if err := options.validate(&v); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
+
+// MultipartReader streams the parts of a multipart/form-data body, enforcing
+// the multipart options: WithMaxBytes limits the whole body, and
+// WithMaxFileSize, WithMaxFiles, WithAllowedTypes and WithAllowedExtensions
+// limit the files
+type MultipartReader struct {
+	mr      *multipart.Reader
+	options readConfig
+	files   int
+}
+
+func NewMultipartReader(r *http.Request, opts ...ReadOption) (*MultipartReader, error) {
+	options := newReadConfig(opts...)
+
+	mediaType, params, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/form-data" {
+		return nil, unsupportedMediaTypeError([]string{"multipart/form-data"})
+	}
+
+	boundary := params["boundary"]
+	if boundary == "" {
+		return nil, invalidFormError(http.ErrMissingBoundary)
+	}
+
+	return &MultipartReader{
+		mr:      multipart.NewReader(options.reader(r.Body), boundary),
+		options: options,
+	}, nil
+}
+
+// NextPart returns the next part of the body, or io.EOF when there are no more
+// parts. The content of the previous part is discarded
+func (m *MultipartReader) NextPart() (*Part, error) {
+	p, err := m.mr.NextPart()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, invalidFormError(err)
+	}
+
+	part := &Part{
+		FormName: p.FormName(),
+		FileName: p.FileName(),
+		Header:   p.Header,
+		r:        p,
+		tempDir:  m.options.tempDir,
+	}
+
+	if part.FileName == "" {
+		return part, nil
+	}
+
+	m.files++
+	if m.options.maxFiles > 0 && m.files > m.options.maxFiles {
+		return nil, NewError(http.StatusRequestEntityTooLarge, "too many files",
+			WithDetails(map[string]int{"limit": m.options.maxFiles}),
+			WithMessageKey("dino.body.too_many_files", m.options.maxFiles),
+		)
+	}
+
+	if err := m.checkExtension(part); err != nil {
+		return nil, err
+	}
+
+	if m.options.maxFileSize > 0 {
+		part.r = &fileSizeReader{r: p, part: part, limit: m.options.maxFileSize, remaining: m.options.maxFileSize}
+	}
+
+	// Sniff the content type from the first bytes and put them back in front
+	// of the rest of the part
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(part, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+
+	part.ContentType = http.DetectContentType(head[:n])
+	part.r = io.MultiReader(bytes.NewReader(head[:n]), part.r)
+
+	if err := m.checkType(part); err != nil {
+		return nil, err
+	}
+
+	return part, nil
+}
+
+func (m *MultipartReader) checkExtension(part *Part) error {
+	if len(m.options.allowedExtensions) == 0 {
+		return nil
+	}
+
+	ext := filepath.Ext(part.FileName)
+	if slices.ContainsFunc(m.options.allowedExtensions, func(allowed string) bool {
+		return strings.EqualFold("."+strings.TrimPrefix(allowed, "."), ext)
+	}) {
+		return nil
+	}
+
+	return NewError(http.StatusUnsupportedMediaType, fmt.Sprintf("file %q has an unsupported extension", part.FileName),
+		WithDetails(map[string]any{"field": part.FormName, "filename": part.FileName, "allowed": m.options.allowedExtensions}),
+		WithMessageKey("dino.body.file_extension", part.FileName, ext),
+	)
+}
+
+func (m *MultipartReader) checkType(part *Part) error {
+	if len(m.options.allowedTypes) == 0 {
+		return nil
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(part.ContentType)
+	if slices.ContainsFunc(m.options.allowedTypes, func(allowed string) bool {
+		if prefix, ok := strings.CutSuffix(allowed, "/*"); ok {
+			return strings.HasPrefix(mediaType, prefix+"/")
+		}
+		return strings.EqualFold(allowed, mediaType)
+	}) {
+		return nil
+	}
+
+	return NewError(http.StatusUnsupportedMediaType, fmt.Sprintf("file %q has an unsupported type", part.FileName),
+		WithDetails(map[string]any{"field": part.FormName, "filename": part.FileName, "type": mediaType, "allowed": m.options.allowedTypes}),
+		WithMessageKey("dino.body.file_type", part.FileName, mediaType),
+	)
+}
+
+// Part is a part of a multipart body. FileName is empty for form values and
+// ContentType is only set for files. Read errors are *Error values that can be
+// returned by the handler as is
+type Part struct {
+	FormName    string
+	FileName    string
+	ContentType string
+	Header      textproto.MIMEHeader
+
+	r       io.Reader
+	tempDir string
+}
+
+func (p *Part) IsFile() bool {
+	return p.FileName != ""
+}
+
+func (p *Part) Read(b []byte) (int, error) {
+	n, err := p.r.Read(b)
+	if err != nil && err != io.EOF {
+		var httpErr *Error
+		if !errors.As(err, &httpErr) {
+			err = invalidFormError(err)
+		}
+	}
+	return n, err
+}
+
+// Spool copies the rest of the part into a temporary file. The caller is
+// responsible for removing it with FormFile.Remove
+func (p *Part) Spool() (*FormFile, error) {
+	f, err := os.CreateTemp(p.tempDir, "dino-upload-*")
+	if err != nil {
+		return nil, fmt.Errorf("dino: spooling upload: %w", err)
+	}
+
+	size, err := io.Copy(f, p)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(f.Name())
+
+		var httpErr *Error
+		if errors.As(err, &httpErr) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("dino: spooling upload: %w", err)
+	}
+
+	return &FormFile{
+		FormName:    p.FormName,
+		FileName:    p.FileName,
+		ContentType: p.ContentType,
+		Size:        size,
+		Path:        f.Name(),
+	}, nil
+}
+
+// fileSizeReader fails with a 413 error once a file goes over the limit
+type fileSizeReader struct {
+	r         io.Reader
+	part      *Part
+	limit     int64
+	remaining int64
+	err       error
+}
+
+func (fr *fileSizeReader) Read(p []byte) (int, error) {
+	if fr.err != nil {
+		return 0, fr.err
+	}
+
+	if int64(len(p)) > fr.remaining+1 {
+		p = p[:fr.remaining+1]
+	}
+
+	n, err := fr.r.Read(p)
+
+	if int64(n) <= fr.remaining {
+		fr.remaining -= int64(n)
+		return n, err
+	}
+
+	n = int(fr.remaining)
+	fr.remaining = 0
+	fr.err = NewError(http.StatusRequestEntityTooLarge, fmt.Sprintf("file %q too large", fr.part.FileName),
+		WithDetails(map[string]any{"field": fr.part.FormName, "filename": fr.part.FileName, "limit": fr.limit}),
+		WithMessageKey("dino.body.file_too_large", fr.part.FileName, fr.limit),
+	)
+
+	return n, fr.err
+}
+
+// FormFile is a file of a multipart body spooled to disk
+type FormFile struct {
+	FormName    string
+	FileName    string
+	ContentType string
+	Size        int64
+	Path        string
+}
+
+func (f *FormFile) Open() (*os.File, error) {
+	return os.Open(f.Path)
+}
+
+func (f *FormFile) Remove() error {
+	return os.Remove(f.Path)
+}
+
+// MultipartForm is a multipart body whose files were spooled to disk
+type MultipartForm struct {
+	Value url.Values
+	File  map[string][]*FormFile
+}
+
+// RemoveAll removes the spooled files
+func (f *MultipartForm) RemoveAll() error {
+	var errs []error
+	for _, files := range f.File {
+		for _, file := range files {
+			if err := file.Remove(); err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ReadMultipart reads a whole multipart/form-data body with a MultipartReader,
+// spooling its files to disk and keeping its values in memory, up to
+// WithMaxValueBytes. The caller is responsible for calling RemoveAll.
+// On error, the files spooled so far are removed
+func ReadMultipart(r *http.Request, opts ...ReadOption) (*MultipartForm, error) {
+	mr, err := NewMultipartReader(r, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	form := &MultipartForm{
+		Value: make(url.Values),
+		File:  make(map[string][]*FormFile),
+	}
+
+	valueBytes := mr.options.maxValueBytes
+	if valueBytes <= 0 {
+		valueBytes = DefaultMaxValueBytes
+	}
+
+	remaining := valueBytes
+
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			return form, nil
+		}
+		if err != nil {
+			form.RemoveAll()
+			return nil, err
+		}
+
+		if !part.IsFile() {
+			// Read one byte more than the budget to detect values over it
+			value, err := io.ReadAll(io.LimitReader(part, remaining+1))
+			if err != nil {
+				form.RemoveAll()
+				return nil, err
+			}
+
+			remaining -= int64(len(value))
+			if remaining < 0 {
+				form.RemoveAll()
+				return nil, NewError(http.StatusRequestEntityTooLarge, "form values too large",
+					WithDetails(map[string]int64{"limit": valueBytes}),
+					WithMessageKey("dino.body.values_too_large", valueBytes),
+				)
+			}
+
+			form.Value.Add(part.FormName, string(value))
+			continue
+		}
+
+		file, err := part.Spool()
+		if err != nil {
+			form.RemoveAll()
+			return nil, err
+		}
+
+		form.File[part.FormName] = append(form.File[part.FormName], file)
+	}
+}
@@ -0,0 +1,268 @@
+package dino
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testPNG = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type testUpload struct {
+	field    string
+	filename string
+	content  []byte
+}
+
+func newMultipartRequest(t *testing.T, values map[string]string, files ...testUpload) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	mw := multipart.NewWriter(&body)
+
+	for name, value := range values {
+		require.NoError(t, mw.WriteField(name, value))
+	}
+
+	for _, file := range files {
+		fw, err := mw.CreateFormFile(file.field, file.filename)
+		require.NoError(t, err)
+		_, err = fw.Write(file.content)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, mw.Close())
+
+	return newBindRequest(mw.FormDataContentType(), body.String())
+}
+
+func requireErrorCode(t *testing.T, err error, code int) *Error {
+	t.Helper()
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, code, httpErr.Code)
+
+	return httpErr
+}
+
+func TestReadForm(t *testing.T) {
+	req := newBindRequest("application/x-www-form-urlencoded", "name=John&age=30")
+
+	result, err := ReadForm[testBindStruct](req)
+
+	require.NoError(t, err)
+	assert.Equal(t, testBindStruct{Name: "John", Age: 30}, result)
+}
+
+func TestReadForm_Multipart(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{"name": "John"}, testUpload{"avatar", "a.png", testPNG})
+
+	result, err := ReadForm[testBindStruct](req)
+
+	require.NoError(t, err)
+	assert.Equal(t, "John", result.Name)
+}
+
+func TestReadForm_Errors(t *testing.T) {
+	_, err := ReadForm[testBindStruct](newBindRequest("application/json", `{}`))
+	requireErrorCode(t, err, http.StatusUnsupportedMediaType)
+
+	_, err = ReadForm[testBindStruct](newBindRequest("application/x-www-form-urlencoded", "age=x"))
+	requireErrorCode(t, err, http.StatusBadRequest)
+
+	_, err = ReadForm[testBindStruct](newBindRequest("application/x-www-form-urlencoded", "name="+strings.Repeat("a", 100)), WithMaxBytes(10))
+	requireErrorCode(t, err, http.StatusRequestEntityTooLarge)
+
+	_, err = ReadForm[testBindValidated](newBindRequest("application/x-www-form-urlencoded", "age=20"))
+	requireErrorCode(t, err, http.StatusUnprocessableEntity)
+}
+
+func TestMultipartReader(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{"title": "Holidays"},
+		testUpload{"photo", "beach.png", testPNG},
+		testUpload{"notes", "notes.txt", []byte("hello")},
+	)
+
+	mr, err := NewMultipartReader(req)
+	require.NoError(t, err)
+
+	part, err := mr.NextPart()
+	require.NoError(t, err)
+	assert.False(t, part.IsFile())
+	assert.Equal(t, "title", part.FormName)
+	value, err := io.ReadAll(part)
+	require.NoError(t, err)
+	assert.Equal(t, "Holidays", string(value))
+
+	part, err = mr.NextPart()
+	require.NoError(t, err)
+	assert.True(t, part.IsFile())
+	assert.Equal(t, "beach.png", part.FileName)
+	assert.Equal(t, "image/png", part.ContentType)
+	content, err := io.ReadAll(part)
+	require.NoError(t, err)
+	assert.Equal(t, testPNG, content, "sniffed bytes are not lost")
+
+	part, err = mr.NextPart()
+	require.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", part.ContentType)
+
+	_, err = mr.NextPart()
+	assert.Equal(t, io.EOF, err)
+}
+
+func TestMultipartReader_NotMultipart(t *testing.T) {
+	_, err := NewMultipartReader(newBindRequest("application/json", `{}`))
+	requireErrorCode(t, err, http.StatusUnsupportedMediaType)
+
+	_, err = NewMultipartReader(newBindRequest("multipart/form-data", ""))
+	requireErrorCode(t, err, http.StatusBadRequest)
+}
+
+func TestMultipartReader_Limits(t *testing.T) {
+	tests := []struct {
+		name  string
+		files []testUpload
+		opts  []ReadOption
+		code  int
+	}{
+		{
+			name:  "file too large",
+			files: []testUpload{{"f", "a.txt", bytes.Repeat([]byte("a"), 1000)}},
+			opts:  []ReadOption{WithMaxFileSize(100)},
+			code:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "file too large after sniffing",
+			files: []testUpload{{"f", "a.txt", bytes.Repeat([]byte("a"), 1000)}},
+			opts:  []ReadOption{WithMaxFileSize(600)},
+			code:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "body too large",
+			files: []testUpload{{"f", "a.txt", bytes.Repeat([]byte("a"), 1000)}, {"g", "b.txt", bytes.Repeat([]byte("b"), 1000)}},
+			opts:  []ReadOption{WithMaxFileSize(1000), WithMaxBytes(1500)},
+			code:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "too many files",
+			files: []testUpload{{"f", "a.txt", []byte("a")}, {"f", "b.txt", []byte("b")}},
+			opts:  []ReadOption{WithMaxFiles(1)},
+			code:  http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:  "extension not allowed",
+			files: []testUpload{{"f", "a.exe", testPNG}},
+			opts:  []ReadOption{WithAllowedExtensions(".png", "jpg")},
+			code:  http.StatusUnsupportedMediaType,
+		},
+		{
+			name:  "type not allowed",
+			files: []testUpload{{"f", "a.png", []byte("not an image")}},
+			opts:  []ReadOption{WithAllowedTypes("image/*")},
+			code:  http.StatusUnsupportedMediaType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form, err := ReadMultipart(newMultipartRequest(t, nil, tt.files...), tt.opts...)
+
+			assert.Nil(t, form)
+			requireErrorCode(t, err, tt.code)
+		})
+	}
+}
+
+func TestMultipartReader_Allowed(t *testing.T) {
+	req := newMultipartRequest(t, nil, testUpload{"f", "A.PNG", testPNG})
+
+	form, err := ReadMultipart(req, WithAllowedExtensions("png"), WithAllowedTypes("image/png"), WithMaxFileSize(int64(len(testPNG))))
+
+	require.NoError(t, err)
+	defer form.RemoveAll()
+	assert.Len(t, form.File["f"], 1)
+}
+
+func TestReadMultipart(t *testing.T) {
+	dir := t.TempDir()
+	req := newMultipartRequest(t, map[string]string{"title": "Holidays"}, testUpload{"photo", "beach.png", testPNG})
+
+	form, err := ReadMultipart(req, WithTempDir(dir))
+	require.NoError(t, err)
+
+	assert.Equal(t, "Holidays", form.Value.Get("title"))
+	require.Len(t, form.File["photo"], 1)
+
+	file := form.File["photo"][0]
+	assert.Equal(t, "beach.png", file.FileName)
+	assert.Equal(t, "image/png", file.ContentType)
+	assert.Equal(t, int64(len(testPNG)), file.Size)
+	assert.Equal(t, dir, filepath.Dir(file.Path))
+
+	f, err := file.Open()
+	require.NoError(t, err)
+	content, err := io.ReadAll(f)
+	f.Close()
+	require.NoError(t, err)
+	assert.Equal(t, testPNG, content)
+
+	require.NoError(t, form.RemoveAll())
+	_, err = os.Stat(file.Path)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestReadMultipart_RemovesFilesOnError(t *testing.T) {
+	dir := t.TempDir()
+	req := newMultipartRequest(t, nil, testUpload{"f", "a.txt", []byte("a")}, testUpload{"f", "b.txt", []byte("b")})
+
+	_, err := ReadMultipart(req, WithTempDir(dir), WithMaxFiles(1))
+	require.Error(t, err)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestReadMultipart_ValueLimit(t *testing.T) {
+	dir := t.TempDir()
+	req := newMultipartRequest(t, map[string]string{"a": strings.Repeat("x", 60), "b": strings.Repeat("y", 60)},
+		testUpload{"f", "a.txt", []byte("a")},
+	)
+
+	form, err := ReadMultipart(req, WithMaxValueBytes(100), WithMaxFileSize(10), WithTempDir(dir))
+
+	assert.Nil(t, form)
+	httpErr := requireErrorCode(t, err, http.StatusRequestEntityTooLarge)
+	assert.Equal(t, map[string]int64{"limit": 100}, httpErr.Details)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestReadMultipart_DefaultValueLimit(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{"big": strings.Repeat("x", DefaultMaxValueBytes+1)})
+
+	_, err := ReadMultipart(req, WithMaxFileSize(10))
+
+	requireErrorCode(t, err, http.StatusRequestEntityTooLarge)
+}
+
+func TestReadMultipart_ValueLimitExact(t *testing.T) {
+	req := newMultipartRequest(t, map[string]string{"a": strings.Repeat("x", 50), "b": strings.Repeat("y", 50)})
+
+	form, err := ReadMultipart(req, WithMaxValueBytes(100))
+
+	require.NoError(t, err)
+	assert.Len(t, form.Value, 2)
+}
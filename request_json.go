@@ -0,0 +1,362 @@
+package dino
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// JSONErrorDetails are the details of the 400 errors returned when a JSON body
+// cannot be decoded. Field is the path of the offending field, if known, and
+// Offset the byte offset in the body where the problem was found. Index and
+// Line locate the record of the streaming readers ReadJSONArray and
+// ReadNDJSON, starting at 0 and 1 respectively
+type JSONErrorDetails struct {
+	Field  string `json:"field,omitempty" xml:"field,omitempty"`
+	Offset int64  `json:"offset" xml:"offset"`
+	Reason string `json:"reason" xml:"reason"`
+	Index  *int   `json:"index,omitempty" xml:"index,omitempty"`
+	Line   int    `json:"line,omitempty" xml:"line,omitempty"`
+}
+
+func invalidJSONError(details JSONErrorDetails, cause error) *Error {
+	opts := []ErrorOption{
+		WithDetails(details),
+		WithMessageKey("dino.body.invalid_json"),
+	}
+
+	// Allows collecting field related body errors in a ValidationError
+	if details.Field != "" {
+		cause = errors.Join(cause, &FieldError{Field: details.Field, Code: "invalid", Message: details.Reason})
+	}
+
+	return NewError(http.StatusBadRequest, "invalid JSON body", append(opts, WithInternalError(cause))...)
+}
+
+func decodeJSON(r io.Reader, v any, options readConfig) error {
+	dec := newJSONDecoder(options.reader(r), options)
+
+	// Matching keys case-sensitively needs the raw value, which is decoded
+	// into v afterwards
+	var value json.RawMessage
+	target := v
+	if options.caseSensitive {
+		target = &value
+	}
+
+	if err := dec.Decode(target); err != nil {
+		return readError(err, invalidJSONError(jsonErrorDetails(err, dec.InputOffset()), err))
+	}
+
+	if options.caseSensitive {
+		// The value is preceded by blanks so the offsets of its errors are
+		// the ones in the body
+		data := append(bytes.Repeat([]byte(" "), int(dec.InputOffset())-len(value)), value...)
+
+		if err := decodeJSONCaseSensitive(data, v, options); err != nil {
+			return err
+		}
+	}
+
+	if options.rejectTrailingData {
+		offset := dec.InputOffset()
+
+		if _, err := dec.Token(); err != io.EOF {
+			return readError(err, invalidJSONError(JSONErrorDetails{
+				Offset: offset,
+				Reason: "unexpected data after JSON value",
+			}, err))
+		}
+	}
+
+	return nil
+}
+
+func newJSONDecoder(r io.Reader, options readConfig) *json.Decoder {
+	dec := json.NewDecoder(r)
+
+	if options.disallowUnknownFields {
+		dec.DisallowUnknownFields()
+	}
+
+	if options.useNumber {
+		dec.UseNumber()
+	}
+
+	return dec
+}
+
+// decodeJSONCaseSensitive decodes the valid JSON value of data into v without
+// the keys that only match a field when ignoring case. data is modified
+func decodeJSONCaseSensitive(data []byte, v any, options readConfig) error {
+	if field, ok := stripJSONCaseMismatches(data, reflect.TypeOf(v)); ok && options.disallowUnknownFields {
+		err := fmt.Errorf("json: unknown field %q", field)
+		return invalidJSONError(JSONErrorDetails{
+			Field:  field,
+			Reason: "unknown field, field names are case-sensitive",
+		}, err)
+	}
+
+	dec := newJSONDecoder(bytes.NewReader(data), options)
+	if err := dec.Decode(v); err != nil {
+		return invalidJSONError(jsonErrorDetails(err, dec.InputOffset()), err)
+	}
+
+	return nil
+}
+
+func jsonErrorDetails(err error, offset int64) JSONErrorDetails {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case errors.As(err, &syntaxErr):
+		return JSONErrorDetails{Offset: syntaxErr.Offset, Reason: syntaxErr.Error()}
+
+	case errors.As(err, &typeErr):
+		return JSONErrorDetails{
+			Field:  typeErr.Field,
+			Offset: typeErr.Offset,
+			Reason: fmt.Sprintf("cannot use a JSON %s as %s", typeErr.Value, jsonTypeName(typeErr.Type)),
+		}
+
+	case errors.Is(err, io.EOF):
+		return JSONErrorDetails{Offset: offset, Reason: "empty body"}
+
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return JSONErrorDetails{Offset: offset, Reason: "unexpected end of JSON input"}
+	}
+
+	// encoding/json has no error type for unknown fields
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		if unquoted, err := strconv.Unquote(field); err == nil {
+			field = unquoted
+		}
+		return JSONErrorDetails{Field: field, Offset: offset, Reason: "unknown field"}
+	}
+
+	return JSONErrorDetails{Offset: offset, Reason: err.Error()}
+}
+
+func jsonTypeName(t reflect.Type) string {
+	if t == nil {
+		return "a value"
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return "a non-negative integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.String:
+		return "a string"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.Map, reflect.Struct:
+		return "an object"
+	case reflect.Pointer:
+		return jsonTypeName(t.Elem())
+	default:
+		return "a value"
+	}
+}
+
+// jsonMember is an object member, from its key to the end of its value. prev
+// is the end of the previous member, or of the opening brace
+type jsonMember struct {
+	prev, start, end int
+	drop             bool
+}
+
+// stripJSONCaseMismatches blanks out the object members of the valid JSON
+// value of data whose key only matches a field of t when ignoring case, so
+// encoding/json ignores them, and returns the path of the first one in body
+// order. Blanks keep the offsets of the rest of data
+func stripJSONCaseMismatches(data []byte, t reflect.Type) (string, bool) {
+	return stripJSONValue(data, t, "")
+}
+
+func stripJSONValue(data []byte, t reflect.Type, path string) (string, bool) {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var first string
+	found := false
+
+	report := func(mismatch string, ok bool) {
+		if ok && !found {
+			first, found = mismatch, true
+		}
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return "", false
+	}
+
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		if tok != json.Delim('{') {
+			return "", false
+		}
+
+		var fields map[string]reflect.Type
+		if t.Kind() == reflect.Struct {
+			fields = jsonFields(t)
+		}
+
+		var members []jsonMember
+
+		for dec.More() {
+			prev := int(dec.InputOffset())
+
+			tok, err := dec.Token()
+			key, ok := tok.(string)
+			if err != nil || !ok {
+				return first, found
+			}
+
+			var value json.RawMessage
+			if dec.Decode(&value) != nil {
+				return first, found
+			}
+
+			m := jsonMember{
+				prev:  prev,
+				start: prev + bytes.IndexByte(data[prev:], '"'),
+				end:   int(dec.InputOffset()),
+			}
+
+			fieldPath := joinFieldPath(path, key)
+
+			if t.Kind() == reflect.Map {
+				report(stripJSONValue(data[m.end-len(value):m.end], t.Elem(), fieldPath))
+			} else if field, ok := fields[key]; ok {
+				report(stripJSONValue(data[m.end-len(value):m.end], field, fieldPath))
+			} else if hasJSONFieldFold(fields, key) {
+				m.drop = true
+				report(fieldPath, true)
+			}
+
+			members = append(members, m)
+		}
+
+		blankJSONMembers(data, members)
+
+	case reflect.Slice, reflect.Array:
+		if tok != json.Delim('[') {
+			return "", false
+		}
+
+		for i := 0; dec.More(); i++ {
+			var value json.RawMessage
+			if dec.Decode(&value) != nil {
+				return first, found
+			}
+
+			end := int(dec.InputOffset())
+			report(stripJSONValue(data[end-len(value):end], t.Elem(), fmt.Sprintf("%s[%d]", path, i)))
+		}
+	}
+
+	return first, found
+}
+
+func hasJSONFieldFold(fields map[string]reflect.Type, key string) bool {
+	for name := range fields {
+		if strings.EqualFold(name, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// blankJSONMembers replaces the dropped members of an object with spaces,
+// together with the comma separating them from a kept member
+func blankJSONMembers(data []byte, members []jsonMember) {
+	kept := false
+
+	for i, m := range members {
+		if !m.drop {
+			kept = true
+			continue
+		}
+
+		var blank []byte
+		switch {
+		case kept:
+			blank = data[m.prev:m.end]
+		case i+1 < len(members):
+			blank = data[m.start:members[i+1].start]
+		default:
+			blank = data[m.start:m.end]
+		}
+
+		for j := range blank {
+			blank[j] = ' '
+		}
+	}
+}
+
+// jsonFields returns the JSON names of the fields of a struct type, including
+// the ones promoted from embedded structs
+func jsonFields(t reflect.Type) map[string]reflect.Type {
+	fields := make(map[string]reflect.Type)
+
+	for i := range t.NumField() {
+		f := t.Field(i)
+
+		tag, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && tag == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				for name, typ := range jsonFields(ft) {
+					if _, ok := fields[name]; !ok {
+						fields[name] = typ
+					}
+				}
+				continue
+			}
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+
+		name := f.Name
+		if tag != "" {
+			name = tag
+		}
+
+		fields[name] = f.Type
+	}
+
+	return fields
+}
+
+func joinFieldPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
@@ -0,0 +1,274 @@
+package dino
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testAddress struct {
+	City string `json:"city"`
+}
+
+type testEmbedded struct {
+	Tenant string `json:"tenant"`
+}
+
+type testStrictStruct struct {
+	testEmbedded
+	Name      string         `json:"name"`
+	Age       int            `json:"age"`
+	Address   testAddress    `json:"address"`
+	Addresses []testAddress  `json:"addresses"`
+	Extra     map[string]any `json:"extra"`
+	Ignored   string         `json:"-"`
+	NoTag     string
+}
+
+func requireJSONErrorDetails(t *testing.T, err error) JSONErrorDetails {
+	t.Helper()
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Equal(t, "invalid JSON body", httpErr.Message)
+
+	details, ok := httpErr.Details.(JSONErrorDetails)
+	require.True(t, ok, "expected details to be JSONErrorDetails")
+
+	return details
+}
+
+func TestReadJSON_ErrorDetails_Syntax(t *testing.T) {
+	_, err := ReadJSON[testStrictStruct](strings.NewReader(`{"name": x}`))
+
+	details := requireJSONErrorDetails(t, err)
+	assert.Empty(t, details.Field)
+	assert.Equal(t, int64(10), details.Offset)
+	assert.Contains(t, details.Reason, "invalid character 'x'")
+}
+
+func TestReadJSON_ErrorDetails_Type(t *testing.T) {
+	_, err := ReadJSON[testStrictStruct](strings.NewReader(`{"name":"John","address":{"city":42}}`))
+
+	details := requireJSONErrorDetails(t, err)
+	assert.Equal(t, "address.city", details.Field)
+	assert.Equal(t, int64(35), details.Offset)
+	assert.Equal(t, "cannot use a JSON number as a string", details.Reason)
+
+	var v ValidationError
+	assert.True(t, v.Collect(err))
+	assert.Equal(t, []FieldError{{Field: "address.city", Code: "invalid", Message: "cannot use a JSON number as a string"}}, v.Errors)
+}
+
+func TestReadJSON_ErrorDetails_EmptyBody(t *testing.T) {
+	_, err := ReadJSON[testStrictStruct](strings.NewReader(``))
+
+	details := requireJSONErrorDetails(t, err)
+	assert.Equal(t, "empty body", details.Reason)
+}
+
+func TestReadJSON_ErrorDetails_Truncated(t *testing.T) {
+	_, err := ReadJSON[testStrictStruct](strings.NewReader(`{"name":"Jo`))
+
+	details := requireJSONErrorDetails(t, err)
+	assert.Equal(t, "unexpected end of JSON input", details.Reason)
+}
+
+func TestReadJSON_WithDisallowUnknownFields(t *testing.T) {
+	body := `{"name":"John","unknown":true}`
+
+	_, err := ReadJSON[testStrictStruct](strings.NewReader(body))
+	require.NoError(t, err)
+
+	_, err = ReadJSON[testStrictStruct](strings.NewReader(body), WithDisallowUnknownFields(true))
+
+	details := requireJSONErrorDetails(t, err)
+	assert.Equal(t, "unknown", details.Field)
+	assert.Equal(t, "unknown field", details.Reason)
+}
+
+func TestReadJSON_WithRejectTrailingData(t *testing.T) {
+	tests := []struct {
+		name      string
+		body      string
+		expectErr bool
+	}{
+		{"single value", `{"name":"John"}`, false},
+		{"trailing whitespace", "{\"name\":\"John\"}\n\t ", false},
+		{"trailing garbage", `{"name":"John"} garbage`, true},
+		{"second value", `{"name":"John"}{"name":"Jane"}`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadJSON[testStrictStruct](strings.NewReader(tt.body))
+			require.NoError(t, err, "trailing data is accepted by default")
+
+			result, err := ReadJSON[testStrictStruct](strings.NewReader(tt.body), WithRejectTrailingData(true))
+
+			if tt.expectErr {
+				details := requireJSONErrorDetails(t, err)
+				assert.Equal(t, int64(15), details.Offset)
+				assert.Equal(t, "unexpected data after JSON value", details.Reason)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, "John", result.Name)
+			}
+		})
+	}
+}
+
+func TestReadJSON_WithUseNumber(t *testing.T) {
+	body := `{"extra":{"id":9007199254740993}}`
+
+	result, err := ReadJSON[testStrictStruct](strings.NewReader(body))
+	require.NoError(t, err)
+	assert.IsType(t, float64(0), result.Extra["id"])
+
+	result, err = ReadJSON[testStrictStruct](strings.NewReader(body), WithUseNumber(true))
+	require.NoError(t, err)
+	assert.Equal(t, json.Number("9007199254740993"), result.Extra["id"])
+}
+
+func TestReadJSON_WithCaseSensitive(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		expected      testStrictStruct
+		expectedField string
+	}{
+		{
+			name:     "exact match",
+			body:     `{"name":"John","tenant":"a","NoTag":"x","address":{"city":"Lisbon"}}`,
+			expected: testStrictStruct{testEmbedded: testEmbedded{Tenant: "a"}, Name: "John", NoTag: "x", Address: testAddress{City: "Lisbon"}},
+		},
+		{
+			name:          "top level",
+			body:          `{"Name":"John","age":30}`,
+			expected:      testStrictStruct{Age: 30},
+			expectedField: "Name",
+		},
+		{
+			name:          "embedded",
+			body:          `{"Tenant":"a"}`,
+			expectedField: "Tenant",
+		},
+		{
+			name:          "untagged",
+			body:          `{"notag":"x"}`,
+			expectedField: "notag",
+		},
+		{
+			name:          "nested",
+			body:          `{"address":{"City":"Lisbon"}}`,
+			expectedField: "address.City",
+		},
+		{
+			name:          "slice",
+			body:          `{"addresses":[{"city":"a"},{"CITY":"b"}]}`,
+			expected:      testStrictStruct{Addresses: []testAddress{{City: "a"}, {}}},
+			expectedField: "addresses[1].CITY",
+		},
+		{
+			name:          "exact key after mismatch",
+			body:          `{"NAME":"Jane", "name":"John"}`,
+			expected:      testStrictStruct{Name: "John"},
+			expectedField: "NAME",
+		},
+		{
+			name:          "mismatch after exact key",
+			body:          `{"name":"John" , "NAME":"Jane"}`,
+			expected:      testStrictStruct{Name: "John"},
+			expectedField: "NAME",
+		},
+		{
+			name:          "only mismatches",
+			body:          `{ "NAME":"Jane","AGE":1 }`,
+			expectedField: "NAME",
+		},
+		{
+			name:     "map values are free",
+			body:     `{"extra":{"Anything":1}}`,
+			expected: testStrictStruct{Extra: map[string]any{"Anything": float64(1)}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Keys that do not match exactly are unknown fields, so they are
+			// ignored unless unknown fields are disallowed
+			result, err := ReadJSON[testStrictStruct](strings.NewReader(tt.body), WithCaseSensitive(true))
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, result)
+
+			_, err = ReadJSON[testStrictStruct](strings.NewReader(tt.body), WithCaseSensitive(true), WithDisallowUnknownFields(true))
+
+			if tt.expectedField == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			details := requireJSONErrorDetails(t, err)
+			assert.Equal(t, tt.expectedField, details.Field)
+			assert.Equal(t, "unknown field, field names are case-sensitive", details.Reason)
+		})
+	}
+}
+
+func TestReadJSON_WithCaseSensitive_FirstMismatch(t *testing.T) {
+	body := `{"address":{"City":"a"},"Name":"b","AGE":1,"Addresses":[],"NoTAG":"c"}`
+
+	// The reported field must not depend on map iteration order
+	for range 20 {
+		_, err := ReadJSON[testStrictStruct](strings.NewReader(body), WithCaseSensitive(true), WithDisallowUnknownFields(true))
+
+		details := requireJSONErrorDetails(t, err)
+		require.Equal(t, "address.City", details.Field)
+	}
+}
+
+func TestReadJSON_WithCaseSensitive_ErrorOffset(t *testing.T) {
+	body := `  {"NAME":"x","age":"old"}`
+
+	// Dropped keys do not change the offsets of the errors
+	_, err := ReadJSON[testStrictStruct](strings.NewReader(body))
+	expected := requireJSONErrorDetails(t, err)
+
+	_, err = ReadJSON[testStrictStruct](strings.NewReader(body), WithCaseSensitive(true))
+
+	details := requireJSONErrorDetails(t, err)
+	assert.Equal(t, "age", details.Field)
+	assert.Equal(t, expected.Offset, details.Offset)
+}
+
+func TestReadJSON_WithCaseSensitive_PointerType(t *testing.T) {
+	_, err := ReadJSON[*testStrictStruct](strings.NewReader(`{"NAME":"John"}`), WithCaseSensitive(true), WithDisallowUnknownFields(true))
+
+	details := requireJSONErrorDetails(t, err)
+	assert.Equal(t, "NAME", details.Field)
+}
+
+func TestSetDefaultReadOptions(t *testing.T) {
+	SetDefaultReadOptions(WithDisallowUnknownFields(true), WithRejectTrailingData(true))
+	defer SetDefaultReadOptions()
+
+	_, err := ReadJSON[testStrictStruct](strings.NewReader(`{"unknown":1}`))
+	require.Error(t, err)
+
+	_, err = ReadJSON[testStrictStruct](strings.NewReader(`{} {}`))
+	require.Error(t, err)
+
+	// Per call options are applied on top of the defaults and can disable them
+	_, err = ReadJSON[testStrictStruct](strings.NewReader(`{"unknown":1} {}`), WithDisallowUnknownFields(false), WithRejectTrailingData(false))
+	require.NoError(t, err)
+
+	_, err = ReadJSON[testStrictStruct](strings.NewReader(`{"name":"John"}`), WithMaxBytes(5))
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+}
@@ -10,54 +10,161 @@ import (
 type paramFrom string
 
 const (
-	fromPath  paramFrom = "URL path"
-	fromQuery paramFrom = "URL query string"
+	fromPath   paramFrom = "URL path"
+	fromQuery  paramFrom = "URL query string"
+	fromForm   paramFrom = "form body"
+	fromHeader paramFrom = "header"
+	fromCookie paramFrom = "cookie"
 )
 
 type Param struct {
-	from  paramFrom
-	name  string
-	value string
+	from    paramFrom
+	name    string
+	value   string
+	present bool
+	checks  []paramCheck
 }
 
-func (p Param) newError(msg string) error {
+// The FieldError cause allows collecting parameter errors in a ValidationError
+// and the message key allows translating them with a MessageCatalog
+func (p Param) newError(code, key, format string, args ...any) error {
+	msg := fmt.Sprintf(format, args...)
+
 	return NewError(
 		http.StatusBadRequest,
 		fmt.Sprintf("parameter %q from %s %s", p.name, p.from, msg),
+		WithInternalError(&FieldError{
+			Field:   p.name,
+			Code:    code,
+			Message: msg,
+			Value:   p.value,
+			key:     "dino.field." + key,
+			args:    args,
+		}),
+		WithMessageKey("dino.param."+key, append([]any{p.name, string(p.from)}, args...)...),
 	)
 }
 
+// PathParam returns the value of the wildcard name. Path values are always
+// set by the router, so an empty value is considered absent
 func PathParam(r *http.Request, name string) Param {
+	value := r.PathValue(name)
+
 	return Param{
-		from:  fromPath,
-		name:  name,
-		value: r.PathValue(name),
+		from:    fromPath,
+		name:    name,
+		value:   value,
+		present: value != "",
 	}
 }
 
 func QueryParam(r *http.Request, name string) Param {
-	return Param{
-		from:  fromQuery,
-		name:  name,
-		value: r.URL.Query().Get(name),
+	values, ok := r.URL.Query()[name]
+
+	p := Param{
+		from:    fromQuery,
+		name:    name,
+		present: ok,
 	}
+
+	if len(values) > 0 {
+		p.value = values[0]
+	}
+
+	return p
 }
 
 func DefaultQueryParam(r *http.Request, name, defaultValue string) Param {
-	p := QueryParam(r, name)
+	return withDefault(QueryParam(r, name), defaultValue)
+}
+
+func RequiredQueryParam(r *http.Request, name string) (Param, error) {
+	return required(QueryParam(r, name))
+}
+
+// HeaderParam returns the first value of the header name
+func HeaderParam(r *http.Request, name string) Param {
+	values := r.Header.Values(name)
+
+	p := Param{
+		from:    fromHeader,
+		name:    name,
+		present: len(values) > 0,
+	}
 
+	if len(values) > 0 {
+		p.value = values[0]
+	}
+
+	return p
+}
+
+func DefaultHeaderParam(r *http.Request, name, defaultValue string) Param {
+	return withDefault(HeaderParam(r, name), defaultValue)
+}
+
+func RequiredHeaderParam(r *http.Request, name string) (Param, error) {
+	return required(HeaderParam(r, name))
+}
+
+func CookieParam(r *http.Request, name string) Param {
+	p := Param{
+		from: fromCookie,
+		name: name,
+	}
+
+	if c, err := r.Cookie(name); err == nil {
+		p.value = c.Value
+		p.present = true
+	}
+
+	return p
+}
+
+func DefaultCookieParam(r *http.Request, name, defaultValue string) Param {
+	return withDefault(CookieParam(r, name), defaultValue)
+}
+
+func RequiredCookieParam(r *http.Request, name string) (Param, error) {
+	return required(CookieParam(r, name))
+}
+
+// FormParam returns the first value of name in the form body. Unlike
+// r.FormValue, values of the URL query string are ignored
+func FormParam(r *http.Request, name string) Param {
+	parsePostForm(r)
+
+	value := r.PostForm.Get(name)
+	_, ok := r.PostForm[name]
+
+	return Param{
+		from:    fromForm,
+		name:    name,
+		value:   value,
+		present: ok,
+	}
+}
+
+func DefaultFormParam(r *http.Request, name, defaultValue string) Param {
+	return withDefault(FormParam(r, name), defaultValue)
+}
+
+func RequiredFormParam(r *http.Request, name string) (Param, error) {
+	return required(FormParam(r, name))
+}
+
+func withDefault(p Param, defaultValue string) Param {
 	if p.value == "" {
 		p.value = defaultValue
+		p.present = true
 	}
 
 	return p
 }
 
-func RequiredQueryParam(r *http.Request, name string) (Param, error) {
-	p := QueryParam(r, name)
-
+func required(p Param) (Param, error) {
 	if p.value == "" {
-		return Param{}, p.newError("is required")
+		return Param{}, p.newError("required", "required", "is required")
 	}
 
 	return p, nil
@@ -70,7 +177,11 @@ func (p Param) String() string {
 func (p Param) Int() (int, error) {
 	v, err := strconv.Atoi(p.value)
 	if err != nil {
-		return 0, p.newError("must be an integer")
+		return 0, p.newError("invalid", "int", "must be an integer")
+	}
+
+	if err := p.check(); err != nil {
+		return 0, err
 	}
 
 	return v, nil
@@ -79,7 +190,11 @@ func (p Param) Int() (int, error) {
 func (p Param) Float() (float64, error) {
 	v, err := strconv.ParseFloat(p.value, 64)
 	if err != nil {
-		return 0, p.newError("must be a float. Example value: 3.14")
+		return 0, p.newError("invalid", "float", "must be a float. Example value: 3.14")
+	}
+
+	if err := p.check(); err != nil {
+		return 0, err
 	}
 
 	return v, nil
@@ -88,7 +203,11 @@ func (p Param) Float() (float64, error) {
 func (p Param) Bool() (bool, error) {
 	v, err := strconv.ParseBool(p.value)
 	if err != nil {
-		return false, p.newError("must be a boolean. Example values: true, false, 1, 0")
+		return false, p.newError("invalid", "bool", "must be a boolean. Example values: true, false, 1, 0")
+	}
+
+	if err := p.check(); err != nil {
+		return false, err
 	}
 
 	return v, nil
@@ -99,7 +218,11 @@ func (p Param) Time(format string) (time.Time, error) {
 	if err != nil {
 		exampleValue := time.Now().Format(format)
 
-		return time.Time{}, p.newError(fmt.Sprintf("must be a time. Example value: %s", exampleValue))
+		return time.Time{}, p.newError("invalid", "time", "must be a time. Example value: %s", exampleValue)
+	}
+
+	if err := p.check(); err != nil {
+		return time.Time{}, err
 	}
 
 	return v, nil
@@ -108,7 +231,11 @@ func (p Param) Time(format string) (time.Time, error) {
 func (p Param) Duration() (time.Duration, error) {
 	v, err := time.ParseDuration(p.value)
 	if err != nil {
-		return 0, p.newError("must be a time duration. Example values: 300ms, -1.5h, 2h45m")
+		return 0, p.newError("invalid", "duration", "must be a time duration. Example values: 300ms, -1.5h, 2h45m")
+	}
+
+	if err := p.check(); err != nil {
+		return 0, err
 	}
 
 	return v, nil
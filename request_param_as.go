@@ -0,0 +1,56 @@
+package dino
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+var parsers = struct {
+	sync.RWMutex
+	m map[reflect.Type]func(string) (any, error)
+}{
+	m: make(map[reflect.Type]func(string) (any, error)),
+}
+
+// RegisterParser registers the function converting parameter values into a
+// T, e.g. a UUID or a decimal type, replacing any parser registered before.
+// Registered parsers take precedence over the built-in conversions and are
+// used by As, BindParams and the form binding of Bind. Values rejected by
+// parse result in an "is invalid" 400 error
+func RegisterParser[T any](parse func(value string) (T, error)) {
+	parsers.Lock()
+	defer parsers.Unlock()
+
+	parsers.m[reflect.TypeFor[T]()] = func(value string) (any, error) {
+		return parse(value)
+	}
+}
+
+func lookupParser(t reflect.Type) (func(string) (any, error), bool) {
+	parsers.RLock()
+	defer parsers.RUnlock()
+
+	parse, ok := parsers.m[t]
+	return parse, ok
+}
+
+// As converts the parameter into a T, which can be any type supported by
+// BindParams: strings, booleans, integers and floats of every width,
+// time.Time (RFC 3339), time.Duration, pointers to them, types implementing
+// encoding.TextUnmarshaler and types with a parser registered with
+// RegisterParser. Other types result in a plain error, as they are
+// programming errors
+func As[T any](p Param) (T, error) {
+	var v, zero T
+
+	if err := p.scan(reflect.ValueOf(&v).Elem(), time.RFC3339); err != nil {
+		return zero, err
+	}
+
+	if err := p.check(); err != nil {
+		return zero, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,173 @@
+package dino
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testColor int
+
+const (
+	testRed testColor = iota + 1
+	testGreen
+)
+
+func parseTestColor(value string) (testColor, error) {
+	switch value {
+	case "red":
+		return testRed, nil
+	case "green":
+		return testGreen, nil
+	}
+	return 0, errors.New("unknown color")
+}
+
+func registerTestParser[T any](t *testing.T, parse func(string) (T, error)) {
+	t.Helper()
+
+	RegisterParser(parse)
+	t.Cleanup(func() {
+		parsers.Lock()
+		delete(parsers.m, reflect.TypeFor[T]())
+		parsers.Unlock()
+	})
+}
+
+func queryParam(value string) Param {
+	return Param{from: fromQuery, name: "v", value: value}
+}
+
+func TestAs_BuiltinTypes(t *testing.T) {
+	i8, err := As[int8](queryParam("-128"))
+	require.NoError(t, err)
+	assert.Equal(t, int8(-128), i8)
+
+	i64, err := As[int64](queryParam(strconv.FormatInt(math.MaxInt64, 10)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), i64)
+
+	u, err := As[uint](queryParam("42"))
+	require.NoError(t, err)
+	assert.Equal(t, uint(42), u)
+
+	u16, err := As[uint16](queryParam("65535"))
+	require.NoError(t, err)
+	assert.Equal(t, uint16(65535), u16)
+
+	f32, err := As[float32](queryParam("1.5"))
+	require.NoError(t, err)
+	assert.Equal(t, float32(1.5), f32)
+
+	tm, err := As[time.Time](queryParam("2024-02-29T10:00:00Z"))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 2, 29, 10, 0, 0, 0, time.UTC), tm)
+
+	d, err := As[time.Duration](queryParam("1m30s"))
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+
+	ptr, err := As[*int](queryParam("7"))
+	require.NoError(t, err)
+	require.NotNil(t, ptr)
+	assert.Equal(t, 7, *ptr)
+}
+
+func TestAs_TextUnmarshaler(t *testing.T) {
+	addr, err := As[netip.Addr](queryParam("10.0.0.1"))
+	require.NoError(t, err)
+	assert.Equal(t, netip.MustParseAddr("10.0.0.1"), addr)
+
+	_, err = As[netip.Addr](queryParam("not-an-ip"))
+	require.Error(t, err)
+	assert.Equal(t, `parameter "v" from URL query string is invalid`, err.Error())
+}
+
+func TestAs_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		as      func(Param) error
+		value   string
+		message string
+	}{
+		{"int8 overflow", func(p Param) error { _, err := As[int8](p); return err }, "128", "must be an integer between -128 and 127"},
+		{"uint negative", func(p Param) error { _, err := As[uint64](p); return err }, "-1", "must be a non-negative integer"},
+		{"uint8 overflow", func(p Param) error { _, err := As[uint8](p); return err }, "256", "must be an integer between 0 and 255"},
+		{"float", func(p Param) error { _, err := As[float64](p); return err }, "x", "must be a float. Example value: 3.14"},
+		{"bool", func(p Param) error { _, err := As[bool](p); return err }, "x", "must be a boolean. Example values: true, false, 1, 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.as(queryParam(tt.value))
+
+			var httpErr *Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Equal(t, `parameter "v" from URL query string `+tt.message, httpErr.Message)
+		})
+	}
+}
+
+func TestAs_UnsupportedType(t *testing.T) {
+	_, err := As[map[string]string](queryParam("x"))
+
+	require.Error(t, err)
+	var httpErr *Error
+	assert.NotErrorAs(t, err, &httpErr, "programming errors are not client errors")
+}
+
+func TestRegisterParser(t *testing.T) {
+	registerTestParser(t, parseTestColor)
+
+	color, err := As[testColor](queryParam("green"))
+	require.NoError(t, err)
+	assert.Equal(t, testGreen, color)
+
+	ptr, err := As[*testColor](queryParam("red"))
+	require.NoError(t, err)
+	assert.Equal(t, testRed, *ptr)
+
+	_, err = As[testColor](queryParam("blue"))
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	assert.Equal(t, "invalid", fe.Code)
+	assert.Equal(t, `parameter "v" from URL query string is invalid`, err.Error())
+}
+
+func TestRegisterParser_BindParams(t *testing.T) {
+	registerTestParser(t, parseTestColor)
+
+	type params struct {
+		Color  testColor   `query:"color"`
+		Colors []testColor `query:"colors"`
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/?color=red&colors=green&colors=red", nil)
+
+	result, err := BindParams[params](req)
+
+	require.NoError(t, err)
+	assert.Equal(t, params{Color: testRed, Colors: []testColor{testGreen, testRed}}, result)
+}
+
+func TestRegisterParser_OverridesBuiltin(t *testing.T) {
+	registerTestParser(t, func(value string) (time.Duration, error) {
+		seconds, err := strconv.Atoi(value)
+		return time.Duration(seconds) * time.Second, err
+	})
+
+	d, err := As[time.Duration](queryParam("90"))
+
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, d)
+}
@@ -0,0 +1,132 @@
+package dino
+
+import (
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// paramCheck checks the value of a parameter after it was converted by an
+// accessor
+type paramCheck func(p Param) error
+
+func (p Param) with(check paramCheck) Param {
+	// Clip so parameters derived from the same Param do not share checks
+	p.checks = append(slices.Clip(p.checks), check)
+	return p
+}
+
+func (p Param) check() error {
+	for _, check := range p.checks {
+		if err := check(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p Param) number() float64 {
+	v, err := strconv.ParseFloat(p.value, 64)
+	if err != nil {
+		return math.NaN()
+	}
+	return v
+}
+
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// Text returns the value of the parameter, failing if it does not satisfy its
+// constraints. It is the String counterpart of the typed accessors
+func (p Param) Text() (string, error) {
+	if err := p.check(); err != nil {
+		return "", err
+	}
+	return p.value, nil
+}
+
+// Min requires a number greater than or equal to n. Like the other
+// constraints, it is checked by the accessors returning an error
+func (p Param) Min(n float64) Param {
+	return p.with(func(p Param) error {
+		if !(p.number() >= n) {
+			return p.newError("min", "min", "must be at least %s", formatNumber(n))
+		}
+		return nil
+	})
+}
+
+// Max requires a number less than or equal to n
+func (p Param) Max(n float64) Param {
+	return p.with(func(p Param) error {
+		if !(p.number() <= n) {
+			return p.newError("max", "max", "must be at most %s", formatNumber(n))
+		}
+		return nil
+	})
+}
+
+// Between requires a number from minValue to maxValue, inclusive
+func (p Param) Between(minValue, maxValue float64) Param {
+	return p.with(func(p Param) error {
+		if v := p.number(); !(v >= minValue && v <= maxValue) {
+			return p.newError("between", "between", "must be between %s and %s", formatNumber(minValue), formatNumber(maxValue))
+		}
+		return nil
+	})
+}
+
+// OneOf requires one of values
+func (p Param) OneOf(values ...string) Param {
+	return p.with(func(p Param) error {
+		if !slices.Contains(values, p.value) {
+			return p.newError("oneof", "oneof", "must be one of: %s", strings.Join(values, ", "))
+		}
+		return nil
+	})
+}
+
+// MatchRegexp requires a value matching re. Use anchors to match the whole
+// value
+func (p Param) MatchRegexp(re *regexp.Regexp) Param {
+	return p.with(func(p Param) error {
+		if !re.MatchString(p.value) {
+			return p.newError("pattern", "pattern", "must match the pattern %s", re.String())
+		}
+		return nil
+	})
+}
+
+// MinLen requires a value of at least n characters
+func (p Param) MinLen(n int) Param {
+	return p.with(func(p Param) error {
+		if utf8.RuneCountInString(p.value) < n {
+			return p.newError("min_len", "min_len", "must be at least %d characters long", n)
+		}
+		return nil
+	})
+}
+
+// MaxLen requires a value of at most n characters
+func (p Param) MaxLen(n int) Param {
+	return p.with(func(p Param) error {
+		if utf8.RuneCountInString(p.value) > n {
+			return p.newError("max_len", "max_len", "must be at most %d characters long", n)
+		}
+		return nil
+	})
+}
+
+// NonEmpty requires a value that is not empty nor made only of spaces
+func (p Param) NonEmpty() Param {
+	return p.with(func(p Param) error {
+		if strings.TrimSpace(p.value) == "" {
+			return p.newError("non_empty", "non_empty", "must not be empty")
+		}
+		return nil
+	})
+}
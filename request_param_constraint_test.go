@@ -0,0 +1,134 @@
+package dino
+
+import (
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParam_NumberConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   Param
+		message string
+	}{
+		{"min ok", queryParam("1").Min(1), ""},
+		{"min", queryParam("0").Min(1), "must be at least 1"},
+		{"max ok", queryParam("100").Max(100), ""},
+		{"max", queryParam("101").Max(100), "must be at most 100"},
+		{"between ok", queryParam("50").Between(1, 100), ""},
+		{"between", queryParam("0").Between(1, 100), "must be between 1 and 100"},
+		{"chained", queryParam("200").Min(1).Max(100), "must be at most 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.param.Int()
+
+			if tt.message == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			var httpErr *Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Equal(t, `parameter "v" from URL query string `+tt.message, httpErr.Message)
+		})
+	}
+}
+
+func TestParam_NumberConstraints_Float(t *testing.T) {
+	_, err := queryParam("0.25").Between(0.5, 1.5).Float()
+
+	require.Error(t, err)
+	assert.Equal(t, `parameter "v" from URL query string must be between 0.5 and 1.5`, err.Error())
+
+	v, err := As[uint8](queryParam("10").Max(10))
+	require.NoError(t, err)
+	assert.Equal(t, uint8(10), v)
+}
+
+func TestParam_ConversionErrorFirst(t *testing.T) {
+	_, err := queryParam("abc").Min(1).Int()
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an integer")
+}
+
+func TestParam_StringConstraints(t *testing.T) {
+	tests := []struct {
+		name    string
+		param   Param
+		message string
+		code    string
+	}{
+		{"oneof ok", queryParam("asc").OneOf("asc", "desc"), "", ""},
+		{"oneof", queryParam("up").OneOf("asc", "desc"), "must be one of: asc, desc", "oneof"},
+		{"pattern ok", queryParam("ab-12").MatchRegexp(regexp.MustCompile(`^[a-z]+-\d+$`)), "", ""},
+		{"pattern", queryParam("ab12").MatchRegexp(regexp.MustCompile(`^[a-z]+-\d+$`)), `must match the pattern ^[a-z]+-\d+$`, "pattern"},
+		{"min len ok", queryParam("ção").MinLen(3), "", ""},
+		{"min len", queryParam("ab").MinLen(3), "must be at least 3 characters long", "min_len"},
+		{"max len", queryParam("abcd").MaxLen(3), "must be at most 3 characters long", "max_len"},
+		{"non empty", queryParam("  ").NonEmpty(), "must not be empty", "non_empty"},
+		{"number on text", queryParam("abc").Min(1), "must be at least 1", "min"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v, err := tt.param.Text()
+
+			if tt.message == "" {
+				require.NoError(t, err)
+				assert.Equal(t, tt.param.String(), v)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Equal(t, `parameter "v" from URL query string `+tt.message, err.Error())
+
+			var fe *FieldError
+			require.ErrorAs(t, err, &fe)
+			assert.Equal(t, tt.code, fe.Code)
+		})
+	}
+}
+
+func TestParam_ConstraintsOnOtherAccessors(t *testing.T) {
+	_, err := queryParam("1h").OneOf("1m", "5m").Duration()
+	assert.Error(t, err)
+
+	_, err = queryParam("2024-01-02").MaxLen(4).Time(time.DateOnly)
+	assert.Error(t, err)
+
+	_, err = queryParam("true").OneOf("1").Bool()
+	assert.Error(t, err)
+}
+
+func TestParam_ConstraintsWithAsReturnZero(t *testing.T) {
+	s, err := As[string](queryParam("abcdef").MaxLen(3))
+	assert.Error(t, err)
+	assert.Equal(t, "", s, "like the other accessors, a failed constraint returns the zero value")
+
+	n, err := As[*int](queryParam("500").Max(100))
+	assert.Error(t, err)
+	assert.Nil(t, n)
+}
+
+func TestParam_ConstraintsDoNotLeak(t *testing.T) {
+	// Three checks leave room in the slice for a fourth one
+	base := queryParam("5").Min(1).Min(2).Min(3)
+
+	strict := base.Max(3)
+	lenient := base.Max(10)
+
+	_, err := strict.Int()
+	assert.Error(t, err)
+
+	_, err = lenient.Int()
+	assert.NoError(t, err)
+}
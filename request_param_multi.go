@@ -0,0 +1,145 @@
+package dino
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// MultiParam is a parameter that may have several values, given by repeating
+// its key, e.g. ?tag=a&tag=b, or by separating them with a delimiter set with
+// Split, e.g. ?tag=a,b. Empty values are ignored, so ?tag= has no values.
+// Errors about a single value name it by its index, e.g. "tag[1]"
+type MultiParam struct {
+	from     paramFrom
+	name     string
+	values   []string
+	minCount int
+	maxCount int
+}
+
+func newMultiParam(from paramFrom, name string, values []string) MultiParam {
+	p := MultiParam{from: from, name: name}
+
+	for _, v := range values {
+		if v != "" {
+			p.values = append(p.values, v)
+		}
+	}
+
+	return p
+}
+
+func QueryParams(r *http.Request, name string) MultiParam {
+	return newMultiParam(fromQuery, name, r.URL.Query()[name])
+}
+
+func HeaderParams(r *http.Request, name string) MultiParam {
+	return newMultiParam(fromHeader, name, r.Header.Values(name))
+}
+
+// FormParams returns the values of name in the form body, ignoring the values
+// of the URL query string
+func FormParams(r *http.Request, name string) MultiParam {
+	parsePostForm(r)
+
+	return newMultiParam(fromForm, name, r.PostForm[name])
+}
+
+// Split splits every value by sep, trimming the spaces around the elements
+func (p MultiParam) Split(sep string) MultiParam {
+	var values []string
+
+	for _, v := range p.values {
+		for elem := range strings.SplitSeq(v, sep) {
+			if elem = strings.TrimSpace(elem); elem != "" {
+				values = append(values, elem)
+			}
+		}
+	}
+
+	p.values = values
+
+	return p
+}
+
+// MinCount makes the accessors fail when there are less than n values
+func (p MultiParam) MinCount(n int) MultiParam {
+	p.minCount = n
+	return p
+}
+
+// MaxCount makes the accessors fail when there are more than n values
+func (p MultiParam) MaxCount(n int) MultiParam {
+	p.maxCount = n
+	return p
+}
+
+func (p MultiParam) Len() int {
+	return len(p.values)
+}
+
+func (p MultiParam) param() Param {
+	return Param{from: p.from, name: p.name, value: strings.Join(p.values, ",")}
+}
+
+func (p MultiParam) elem(i int) Param {
+	return Param{from: p.from, name: fmt.Sprintf("%s[%d]", p.name, i), value: p.values[i]}
+}
+
+func (p MultiParam) checkCount() error {
+	switch {
+	case p.minCount > 0 && len(p.values) < p.minCount:
+		return p.param().newError("count", "min_count", "must have at least %d values", p.minCount)
+	case p.maxCount > 0 && len(p.values) > p.maxCount:
+		return p.param().newError("count", "max_count", "must have at most %d values", p.maxCount)
+	}
+	return nil
+}
+
+func scanMulti[T any](p MultiParam, parse func(Param) (T, error)) ([]T, error) {
+	if err := p.checkCount(); err != nil {
+		return nil, err
+	}
+
+	result := make([]T, len(p.values))
+
+	for i := range p.values {
+		v, err := parse(p.elem(i))
+		if err != nil {
+			return nil, err
+		}
+		result[i] = v
+	}
+
+	return result, nil
+}
+
+func (p MultiParam) Strings() ([]string, error) {
+	return scanMulti(p, func(e Param) (string, error) {
+		return e.String(), nil
+	})
+}
+
+func (p MultiParam) Ints() ([]int, error) {
+	return scanMulti(p, Param.Int)
+}
+
+func (p MultiParam) Floats() ([]float64, error) {
+	return scanMulti(p, Param.Float)
+}
+
+func (p MultiParam) Bools() ([]bool, error) {
+	return scanMulti(p, Param.Bool)
+}
+
+func (p MultiParam) Times(format string) ([]time.Time, error) {
+	return scanMulti(p, func(e Param) (time.Time, error) {
+		return e.Time(format)
+	})
+}
+
+func (p MultiParam) Durations() ([]time.Duration, error) {
+	return scanMulti(p, Param.Duration)
+}
@@ -0,0 +1,127 @@
+package dino
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryParams(t *testing.T) {
+	tests := []struct {
+		name     string
+		url      string
+		sep      string
+		expected []string
+	}{
+		{"repeated keys", "/items?tag=a&tag=b", "", []string{"a", "b"}},
+		{"delimiter", "/items?tag=a,%20b,,c", ",", []string{"a", "b", "c"}},
+		{"repeated and delimiter", "/items?tag=a|b&tag=c", "|", []string{"a", "b", "c"}},
+		{"empty value", "/items?tag=", "", []string{}},
+		{"missing", "/items", ",", []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := QueryParams(httptest.NewRequest(http.MethodGet, tt.url, nil), "tag")
+			if tt.sep != "" {
+				p = p.Split(tt.sep)
+			}
+
+			values, err := p.Strings()
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, values)
+			assert.Equal(t, len(tt.expected), p.Len())
+		})
+	}
+}
+
+func TestMultiParam_TypedAccessors(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?id=1,2,3&price=1.5&price=2&flag=true,0&day=2024-01-02&ttl=1s,2m", nil)
+
+	ids, err := QueryParams(req, "id").Split(",").Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	prices, err := QueryParams(req, "price").Floats()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{1.5, 2}, prices)
+
+	flags, err := QueryParams(req, "flag").Split(",").Bools()
+	require.NoError(t, err)
+	assert.Equal(t, []bool{true, false}, flags)
+
+	days, err := QueryParams(req, "day").Times(time.DateOnly)
+	require.NoError(t, err)
+	assert.Equal(t, []time.Time{time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)}, days)
+
+	ttls, err := QueryParams(req, "ttl").Split(",").Durations()
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Minute}, ttls)
+}
+
+func TestMultiParam_InvalidElement(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?id=1&id=x&id=y", nil)
+
+	_, err := QueryParams(req, "id").Ints()
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+	assert.Equal(t, `parameter "id[1]" from URL query string must be an integer`, httpErr.Message)
+
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	assert.Equal(t, "id[1]", fe.Field)
+	assert.Equal(t, "x", fe.Value)
+}
+
+func TestMultiParam_Count(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?id=1,2,3", nil)
+	p := QueryParams(req, "id").Split(",")
+
+	_, err := p.MinCount(4).Ints()
+	require.Error(t, err)
+	assert.Equal(t, `parameter "id" from URL query string must have at least 4 values`, err.Error())
+
+	_, err = p.MaxCount(2).Strings()
+	require.Error(t, err)
+	assert.Equal(t, `parameter "id" from URL query string must have at most 2 values`, err.Error())
+
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	assert.Equal(t, "count", fe.Code)
+
+	ids, err := p.MinCount(1).MaxCount(3).Ints()
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3}, ids)
+
+	_, err = QueryParams(req, "missing").MinCount(1).Strings()
+	assert.Error(t, err)
+}
+
+func TestHeaderParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items", nil)
+	req.Header.Add("Accept-Language", "en, pt")
+	req.Header.Add("Accept-Language", "fr")
+
+	values, err := HeaderParams(req, "Accept-Language").Split(",").Strings()
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"en", "pt", "fr"}, values)
+}
+
+func TestFormParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/items?id=9", strings.NewReader("id=1&id=2"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	ids, err := FormParams(req, "id").Ints()
+
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, ids)
+}
@@ -0,0 +1,68 @@
+package dino
+
+import (
+	"reflect"
+)
+
+// Present reports whether the request contains the parameter, even with an
+// empty value as in ?x=. Parameters with a default value are always present
+func (p Param) Present() bool {
+	return p.present
+}
+
+// hasValue reports whether the parameter has a value to convert into a T. An
+// empty value only counts for strings and pointers to strings, since ?x= is a
+// valid empty string but not a valid number
+func hasValue[T any](p Param) bool {
+	if !p.present {
+		return false
+	}
+
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return p.value != "" || t.Kind() == reflect.String
+}
+
+// Lookup converts the parameter into a T like As, but an absent parameter is
+// not an error: it returns the zero value and false. A present but empty
+// value is also treated as absent, except for strings
+func Lookup[T any](p Param) (T, bool, error) {
+	var zero T
+
+	if !hasValue[T](p) {
+		return zero, false, nil
+	}
+
+	v, err := As[T](p)
+	if err != nil {
+		return zero, true, err
+	}
+
+	return v, true, nil
+}
+
+// Optional is like Lookup, returning nil for an absent parameter
+func Optional[T any](p Param) (*T, error) {
+	v, ok, err := Lookup[T](p)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// AsOr is like Lookup, returning defaultValue for an absent parameter. Unlike
+// the string defaults of DefaultQueryParam and similar constructors,
+// defaultValue is not checked against the constraints of the parameter
+func AsOr[T any](p Param, defaultValue T) (T, error) {
+	v, ok, err := Lookup[T](p)
+	if err != nil {
+		return v, err
+	}
+	if !ok {
+		return defaultValue, nil
+	}
+	return v, nil
+}
@@ -0,0 +1,127 @@
+package dino
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParam_Present(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/items?page=&limit=10", strings.NewReader("name="))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Empty", "")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	req.SetPathValue("id", "")
+
+	assert.True(t, QueryParam(req, "page").Present())
+	assert.True(t, QueryParam(req, "limit").Present())
+	assert.False(t, QueryParam(req, "sort").Present())
+	assert.True(t, DefaultQueryParam(req, "sort", "asc").Present())
+
+	assert.True(t, HeaderParam(req, "x-empty").Present())
+	assert.False(t, HeaderParam(req, "X-Missing").Present())
+
+	assert.True(t, CookieParam(req, "session").Present())
+	assert.False(t, CookieParam(req, "lang").Present())
+
+	assert.True(t, FormParam(req, "name").Present())
+	assert.False(t, FormParam(req, "age").Present())
+
+	assert.False(t, PathParam(req, "id").Present())
+}
+
+func TestOptional(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?page=&limit=10&q=&bad=x", nil)
+
+	page, err := Optional[int](QueryParam(req, "page"))
+	require.NoError(t, err, "an empty number is treated as absent")
+	assert.Nil(t, page)
+
+	missing, err := Optional[int](QueryParam(req, "missing"))
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	limit, err := Optional[int](QueryParam(req, "limit"))
+	require.NoError(t, err)
+	require.NotNil(t, limit)
+	assert.Equal(t, 10, *limit)
+
+	q, err := Optional[string](QueryParam(req, "q"))
+	require.NoError(t, err)
+	require.NotNil(t, q, "an empty string is present")
+	assert.Equal(t, "", *q)
+
+	sort, err := Optional[string](QueryParam(req, "sort"))
+	require.NoError(t, err)
+	assert.Nil(t, sort)
+
+	_, err = Optional[int](QueryParam(req, "bad"))
+	require.Error(t, err)
+	assert.Equal(t, `parameter "bad" from URL query string must be an integer`, err.Error())
+}
+
+func TestLookup(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?ttl=5m&limit=500", nil)
+
+	ttl, ok, err := Lookup[time.Duration](QueryParam(req, "ttl"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 5*time.Minute, ttl)
+
+	_, ok, err = Lookup[time.Duration](QueryParam(req, "timeout"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	_, ok, err = Lookup[int](QueryParam(req, "limit").Max(100))
+	assert.True(t, ok)
+	assert.Error(t, err, "constraints are checked on present values")
+
+	_, ok, err = Lookup[int](QueryParam(req, "offset").Min(1))
+	assert.False(t, ok)
+	assert.NoError(t, err, "constraints are not checked on absent values")
+}
+
+func TestLookup_EmptyValue(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?q=&page=", nil)
+
+	q, ok, err := Lookup[string](QueryParam(req, "q"))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, "", q)
+
+	qp, ok, err := Lookup[*string](QueryParam(req, "q"))
+	require.NoError(t, err)
+	assert.True(t, ok, "pointers to strings accept empty values like strings")
+	require.NotNil(t, qp)
+	assert.Equal(t, "", *qp)
+
+	page, ok, err := Lookup[*int](QueryParam(req, "page"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Nil(t, page)
+}
+
+func TestAsOr(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/items?page=3&size=&verbose=x", nil)
+
+	page, err := AsOr(QueryParam(req, "page"), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 3, page)
+
+	size, err := AsOr(QueryParam(req, "size"), 20)
+	require.NoError(t, err)
+	assert.Equal(t, 20, size)
+
+	since, err := AsOr(QueryParam(req, "since"), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), since)
+
+	verbose, err := AsOr(QueryParam(req, "verbose"), true)
+	require.Error(t, err)
+	assert.False(t, verbose)
+}
@@ -0,0 +1,124 @@
+package dino
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var (
+	timeType            = reflect.TypeFor[time.Time]()
+	durationType        = reflect.TypeFor[time.Duration]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+)
+
+// scan converts the parameter into rv, which must be addressable. The typed
+// accessors of Param are used where possible so errors are reported the same
+// way. timeFormat is only used for time.Time
+func (p Param) scan(rv reflect.Value, timeFormat string) error {
+	if parse, ok := lookupParser(rv.Type()); ok {
+		v, err := parse(p.value)
+		if err != nil {
+			return p.newError("invalid", "invalid", "is invalid")
+		}
+		if v == nil {
+			rv.SetZero()
+		} else {
+			rv.Set(reflect.ValueOf(v))
+		}
+		return nil
+	}
+
+	if rv.Kind() == reflect.Pointer {
+		ptr := reflect.New(rv.Type().Elem())
+		if err := p.scan(ptr.Elem(), timeFormat); err != nil {
+			return err
+		}
+		rv.Set(ptr)
+		return nil
+	}
+
+	// time.Time implements encoding.TextUnmarshaler, but only for RFC 3339
+	switch rv.Type() {
+	case timeType:
+		v, err := p.Time(timeFormat)
+		if err != nil {
+			return err
+		}
+		rv.Set(reflect.ValueOf(v))
+		return nil
+
+	case durationType:
+		v, err := p.Duration()
+		if err != nil {
+			return err
+		}
+		rv.SetInt(int64(v))
+		return nil
+	}
+
+	if rv.Addr().Type().Implements(textUnmarshalerType) {
+		if err := rv.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(p.value)); err != nil {
+			return p.newError("invalid", "invalid", "is invalid")
+		}
+		return nil
+	}
+
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(p.value)
+
+	case reflect.Bool:
+		v, err := p.Bool()
+		if err != nil {
+			return err
+		}
+		rv.SetBool(v)
+
+	case reflect.Int, reflect.Int64:
+		v, err := strconv.ParseInt(p.value, 10, 64)
+		if err != nil {
+			return p.newError("invalid", "int", "must be an integer")
+		}
+		rv.SetInt(v)
+
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		bits := rv.Type().Bits()
+		v, err := strconv.ParseInt(p.value, 10, bits)
+		if err != nil {
+			minValue, maxValue := -int64(1)<<(bits-1), int64(1)<<(bits-1)-1
+			return p.newError("invalid", "int_range", "must be an integer between %d and %d", minValue, maxValue)
+		}
+		rv.SetInt(v)
+
+	case reflect.Uint, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(p.value, 10, 64)
+		if err != nil {
+			return p.newError("invalid", "uint", "must be a non-negative integer")
+		}
+		rv.SetUint(v)
+
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		bits := rv.Type().Bits()
+		v, err := strconv.ParseUint(p.value, 10, bits)
+		if err != nil {
+			return p.newError("invalid", "int_range", "must be an integer between %d and %d", 0, uint64(math.MaxUint64>>(64-bits)))
+		}
+		rv.SetUint(v)
+
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(p.value, rv.Type().Bits())
+		if err != nil {
+			return p.newError("invalid", "float", "must be a float. Example value: 3.14")
+		}
+		rv.SetFloat(v)
+
+	default:
+		return fmt.Errorf("dino: unsupported parameter type %s", rv.Type())
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package dino
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -132,6 +133,68 @@ func TestRequiredQueryParam(t *testing.T) {
 	}
 }
 
+func TestHeaderParam(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.Header.Set("X-Request-Id", "42")
+
+	param := HeaderParam(req, "x-request-id")
+
+	assert.Equal(t, "42", param.String())
+	assert.Equal(t, fromHeader, param.from)
+
+	assert.Equal(t, "fallback", DefaultHeaderParam(req, "X-Missing", "fallback").String())
+	assert.Equal(t, "42", DefaultHeaderParam(req, "X-Request-Id", "fallback").String())
+
+	_, err := RequiredHeaderParam(req, "X-Tenant")
+	require.Error(t, err)
+	assert.Equal(t, `parameter "X-Tenant" from header is required`, err.Error())
+
+	_, err = HeaderParam(req, "X-Request-Id").Bool()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `parameter "X-Request-Id" from header must be a boolean`)
+}
+
+func TestCookieParam(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/users", nil)
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+
+	param := CookieParam(req, "session")
+
+	assert.Equal(t, "abc", param.String())
+	assert.Equal(t, fromCookie, param.from)
+	assert.Equal(t, "", CookieParam(req, "missing").String())
+
+	assert.Equal(t, "en", DefaultCookieParam(req, "lang", "en").String())
+
+	param, err := RequiredCookieParam(req, "session")
+	require.NoError(t, err)
+	assert.Equal(t, "abc", param.String())
+
+	_, err = RequiredCookieParam(req, "lang")
+	require.Error(t, err)
+	assert.Equal(t, `parameter "lang" from cookie is required`, err.Error())
+}
+
+func TestFormParam(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/users?page=2", strings.NewReader("age=30&name="))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	age, err := FormParam(req, "age").Int()
+	require.NoError(t, err)
+	assert.Equal(t, 30, age)
+
+	assert.Equal(t, "", FormParam(req, "page").String(), "query values are ignored")
+	assert.Equal(t, "anonymous", DefaultFormParam(req, "name", "anonymous").String())
+
+	_, err = RequiredFormParam(req, "name")
+	require.Error(t, err)
+	assert.Equal(t, `parameter "name" from form body is required`, err.Error())
+
+	var fe *FieldError
+	require.ErrorAs(t, err, &fe)
+	assert.Equal(t, "required", fe.Code)
+}
+
 func TestParam_String(t *testing.T) {
 	req := httptest.NewRequest(http.MethodGet, "/test?value=hello", nil)
 	param := QueryParam(req, "value")
@@ -0,0 +1,335 @@
+package dino
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"net/http"
+	"reflect"
+)
+
+// DefaultMaxRecordSize is the default size limit of the records read by
+// ReadJSONArray and ReadNDJSON
+const DefaultMaxRecordSize = 1 << 20
+
+// WithMaxRecordSize limits each record read by ReadJSONArray and ReadNDJSON
+// to n bytes, which bounds the memory used to read them. A value <= 0 selects
+// DefaultMaxRecordSize
+func WithMaxRecordSize(n int64) ReadOption {
+	return func(options *readConfig) {
+		options.maxRecordSize = n
+	}
+}
+
+func (options readConfig) recordSize() int64 {
+	if options.maxRecordSize <= 0 {
+		return DefaultMaxRecordSize
+	}
+	return options.maxRecordSize
+}
+
+var errRecordTooLarge = errors.New("dino: record too large")
+
+// recordTooLargeError locates the record, if known, like JSONErrorDetails
+func recordTooLargeError(limit int64, rec *jsonRecord) *Error {
+	details := map[string]any{"limit": limit}
+	if rec != nil {
+		details["index"] = rec.index
+		if rec.line > 0 {
+			details["line"] = rec.line
+		}
+	}
+
+	return NewError(http.StatusRequestEntityTooLarge, "JSON record too large",
+		WithDetails(details),
+		WithMessageKey("dino.body.record_too_large", limit),
+	)
+}
+
+// recordLimitReader fails with errRecordTooLarge when more than limit bytes
+// are read since start. The decoder only reads more when the value it is
+// decoding does not fit in what it has read, so the value is over the limit
+type recordLimitReader struct {
+	r     io.Reader
+	read  int64
+	start int64
+	limit int64
+}
+
+// separatorSize returns the size of the comma and whitespace preceding the
+// next element in the buffered input of the decoder, which are not part of
+// the element
+func separatorSize(buffered io.Reader) int64 {
+	var size int64
+	var b [1]byte
+
+	for {
+		if _, err := buffered.Read(b[:]); err != nil {
+			return size
+		}
+
+		switch b[0] {
+		case ' ', '\t', '\r', '\n', ',':
+			size++
+		default:
+			return size
+		}
+	}
+}
+
+func (lr *recordLimitReader) Read(p []byte) (int, error) {
+	remaining := lr.start + lr.limit + 1 - lr.read
+	if remaining <= 0 {
+		return 0, errRecordTooLarge
+	}
+
+	if int64(len(p)) > remaining {
+		p = p[:remaining]
+	}
+
+	n, err := lr.r.Read(p)
+	lr.read += int64(n)
+
+	return n, err
+}
+
+// jsonRecord is the position of a record in a streamed body
+type jsonRecord struct {
+	index  int
+	line   int
+	offset int64
+}
+
+func (rec jsonRecord) path() string {
+	return fmt.Sprintf("[%d]", rec.index)
+}
+
+// locate makes the details of an error found in the record relative to the
+// whole body
+func (rec jsonRecord) locate(details JSONErrorDetails) JSONErrorDetails {
+	index := rec.index
+
+	if details.Field != "" {
+		details.Field = rec.path() + "." + details.Field
+	}
+	details.Offset += rec.offset
+	details.Index = &index
+	details.Line = rec.line
+
+	return details
+}
+
+// ReadJSONArray returns an iterator decoding the elements of a top-level JSON
+// array one at a time, so arbitrarily large arrays can be processed with
+// bounded memory. Elements are limited by WithMaxRecordSize and validated with
+// Validate, unless the WithoutValidation option is given.
+//
+// An element that cannot be decoded or is invalid yields an error whose
+// details locate it, and the iteration goes on with the next element. Errors
+// that prevent reading the rest of the body, such as malformed JSON or an
+// element over the size limit, yield an error and end the iteration. The body
+// is consumed by the first iteration
+func ReadJSONArray[T any](r io.Reader, opts ...ReadOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		options := newReadConfig(opts...)
+		lr := &recordLimitReader{r: options.reader(r), limit: options.recordSize()}
+		dec := json.NewDecoder(lr)
+
+		fail := func(err error, rec *jsonRecord) {
+			if errors.Is(err, errRecordTooLarge) {
+				yield(zero, recordTooLargeError(lr.limit, rec))
+				return
+			}
+
+			details := jsonErrorDetails(err, dec.InputOffset())
+			if rec != nil {
+				details.Index = &rec.index
+			}
+			yield(zero, readError(err, invalidJSONError(details, err)))
+		}
+
+		tok, err := dec.Token()
+		if err != nil {
+			fail(err, nil)
+			return
+		}
+
+		if tok != json.Delim('[') {
+			err := fmt.Errorf("json: expected an array, got %v", tok)
+			yield(zero, invalidJSONError(JSONErrorDetails{Reason: "expected a JSON array"}, err))
+			return
+		}
+
+		// The limit applies from the end of the previous token, so that looking
+		// for the next element reads at most limit bytes, and then from the
+		// start of the element
+		lr.start = dec.InputOffset()
+
+		for i := 0; dec.More(); i++ {
+			lr.start = dec.InputOffset() + separatorSize(dec.Buffered())
+
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				fail(err, &jsonRecord{index: i})
+				return
+			}
+
+			rec := jsonRecord{index: i, offset: dec.InputOffset() - int64(len(raw))}
+
+			// Numbers are only complete once the byte after them is read, so the
+			// reader allows one more byte than the limit
+			if int64(len(raw)) > lr.limit {
+				fail(errRecordTooLarge, &rec)
+				return
+			}
+
+			v, err := decodeJSONRecord[T](raw, rec, options)
+			if !yield(v, err) {
+				return
+			}
+
+			lr.start = dec.InputOffset()
+		}
+
+		if _, err := dec.Token(); err != nil {
+			fail(err, nil)
+			return
+		}
+
+		if options.rejectTrailingData {
+			offset := dec.InputOffset()
+			lr.start = offset
+
+			if _, err := dec.Token(); err != io.EOF {
+				yield(zero, readError(err, invalidJSONError(JSONErrorDetails{
+					Offset: offset,
+					Reason: "unexpected data after JSON value",
+				}, err)))
+			}
+		}
+	}
+}
+
+// ReadNDJSON returns an iterator decoding the values of a newline-delimited
+// JSON body, one per line, with the same error handling as ReadJSONArray.
+// Since a malformed line or a line over the size limit does not prevent
+// reading the next ones, only errors reading the body end the iteration.
+// Blank lines are skipped
+func ReadNDJSON[T any](r io.Reader, opts ...ReadOption) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		options := newReadConfig(opts...)
+		br := bufio.NewReader(options.reader(r))
+		limit := options.recordSize()
+
+		var offset int64
+		index := 0
+
+		for line := 1; ; line++ {
+			data, size, err := readRecordLine(br, limit)
+			if err != nil && err != io.EOF {
+				yield(zero, unreadableBodyError(err))
+				return
+			}
+
+			if data == nil && size > 0 {
+				rec := jsonRecord{index: index, line: line, offset: offset}
+				index++
+
+				if !yield(zero, recordTooLargeError(limit, &rec)) {
+					return
+				}
+			} else if len(bytes.TrimSpace(data)) > 0 {
+				rec := jsonRecord{index: index, line: line, offset: offset}
+				index++
+
+				v, err := decodeJSONRecord[T](data, rec, options)
+				if !yield(v, err) {
+					return
+				}
+			}
+
+			if err == io.EOF {
+				return
+			}
+
+			offset += size
+		}
+	}
+}
+
+// readRecordLine reads the next line, including its newline, and returns it
+// together with its size. Lines longer than limit, not counting the newline,
+// are discarded and returned as nil
+func readRecordLine(br *bufio.Reader, limit int64) ([]byte, int64, error) {
+	var data []byte
+	var size int64
+
+	for {
+		chunk, err := br.ReadSlice('\n')
+		size += int64(len(chunk))
+
+		if size <= limit+1 {
+			data = append(data, chunk...)
+		} else {
+			data = nil
+		}
+
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+
+		if int64(len(bytes.TrimSuffix(data, []byte("\n")))) > limit {
+			data = nil
+		}
+
+		return data, size, err
+	}
+}
+
+// decodeJSONRecord decodes and validates a single record, which must contain
+// exactly one JSON value. data is modified
+func decodeJSONRecord[T any](data []byte, rec jsonRecord, options readConfig) (T, error) {
+	var v T
+
+	// Malformed records are reported by the decoder below
+	if options.caseSensitive && json.Valid(data) {
+		if field, ok := stripJSONCaseMismatches(data, reflect.TypeOf(&v)); ok && options.disallowUnknownFields {
+			err := fmt.Errorf("json: unknown field %q", field)
+			return v, invalidJSONError(rec.locate(JSONErrorDetails{
+				Field:  field,
+				Reason: "unknown field, field names are case-sensitive",
+			}), err)
+		}
+	}
+
+	dec := newJSONDecoder(bytes.NewReader(data), options)
+
+	if err := dec.Decode(&v); err != nil {
+		return v, invalidJSONError(rec.locate(jsonErrorDetails(err, dec.InputOffset())), err)
+	}
+
+	offset := dec.InputOffset()
+	if _, err := dec.Token(); err != io.EOF {
+		if err == nil {
+			err = errors.New("json: unexpected data after JSON value")
+		}
+		return v, invalidJSONError(rec.locate(JSONErrorDetails{
+			Offset: offset,
+			Reason: "unexpected data after JSON value",
+		}), err)
+	}
+
+	if err := options.validateAt(&v, rec.path()); err != nil {
+		return v, err
+	}
+
+	return v, nil
+}
@@ -0,0 +1,255 @@
+package dino
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRecord struct {
+	ID   int    `json:"id"`
+	Name string `json:"name" validate:"required"`
+}
+
+type streamResult struct {
+	records []testRecord
+	errs    map[int]error
+}
+
+func collectStream(seq func(yield func(testRecord, error) bool)) streamResult {
+	result := streamResult{errs: make(map[int]error)}
+
+	i := 0
+	for v, err := range seq {
+		if err != nil {
+			result.errs[i] = err
+		} else {
+			result.records = append(result.records, v)
+		}
+		i++
+	}
+
+	return result
+}
+
+func TestReadJSONArray(t *testing.T) {
+	body := ` [ {"id":1,"name":"a"}, {"id":2,"name":"b"} ] `
+
+	result := collectStream(ReadJSONArray[testRecord](strings.NewReader(body)))
+
+	assert.Empty(t, result.errs)
+	assert.Equal(t, []testRecord{{1, "a"}, {2, "b"}}, result.records)
+}
+
+func TestReadJSONArray_Empty(t *testing.T) {
+	result := collectStream(ReadJSONArray[testRecord](strings.NewReader(`[]`)))
+
+	assert.Empty(t, result.errs)
+	assert.Empty(t, result.records)
+}
+
+func TestReadJSONArray_RecordErrors(t *testing.T) {
+	body := `[{"id":1,"name":"a"},{"id":"x","name":"b"},{"id":3},{"id":4,"name":"d"}]`
+
+	result := collectStream(ReadJSONArray[testRecord](strings.NewReader(body)))
+
+	assert.Equal(t, []testRecord{{1, "a"}, {4, "d"}}, result.records)
+	require.Len(t, result.errs, 2)
+
+	details := requireJSONErrorDetails(t, result.errs[1])
+	assert.Equal(t, "[1].id", details.Field)
+	assert.Equal(t, 1, *details.Index)
+	assert.Equal(t, int64(strings.Index(body, `"x"`)+3), details.Offset)
+
+	var verr *ValidationError
+	require.ErrorAs(t, result.errs[2], &verr)
+	assert.Equal(t, "[2].name", verr.Errors[0].Field)
+}
+
+func TestReadJSONArray_FatalErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		count int
+		code  int
+	}{
+		{"empty body", ``, 1, http.StatusBadRequest},
+		{"not an array", `{"id":1}`, 1, http.StatusBadRequest},
+		{"malformed element", `[{"id":1,"name":"a"},{"id":}]`, 2, http.StatusBadRequest},
+		{"unterminated", `[{"id":1,"name":"a"}`, 2, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count := 0
+			var lastErr error
+
+			for _, err := range ReadJSONArray[testRecord](strings.NewReader(tt.body)) {
+				count++
+				lastErr = err
+			}
+
+			assert.Equal(t, tt.count, count)
+
+			var httpErr *Error
+			require.ErrorAs(t, lastErr, &httpErr)
+			assert.Equal(t, tt.code, httpErr.Code)
+		})
+	}
+}
+
+func TestReadJSONArray_Options(t *testing.T) {
+	body := `[{"id":1,"name":"a","extra":true}] []`
+
+	result := collectStream(ReadJSONArray[testRecord](strings.NewReader(body), WithDisallowUnknownFields(true), WithRejectTrailingData(true)))
+
+	require.Len(t, result.errs, 2)
+	assert.Equal(t, "[0].extra", requireJSONErrorDetails(t, result.errs[0]).Field)
+	assert.Equal(t, "unexpected data after JSON value", requireJSONErrorDetails(t, result.errs[1]).Reason)
+
+	result = collectStream(ReadJSONArray[testRecord](strings.NewReader(`[{"ID":1,"name":"a"}]`), WithCaseSensitive(true)))
+	require.Empty(t, result.errs)
+	assert.Equal(t, []testRecord{{0, "a"}}, result.records)
+
+	result = collectStream(ReadJSONArray[testRecord](strings.NewReader(`[{"ID":1,"name":"a"}]`), WithCaseSensitive(true), WithDisallowUnknownFields(true)))
+	require.Len(t, result.errs, 1)
+	assert.Equal(t, "[0].ID", requireJSONErrorDetails(t, result.errs[0]).Field)
+}
+
+func TestReadJSONArray_MaxBytes(t *testing.T) {
+	body := `[{"id":1,"name":"a"},{"id":2,"name":"b"}]`
+
+	result := collectStream(ReadJSONArray[testRecord](strings.NewReader(body), WithMaxBytes(25)))
+
+	assert.Equal(t, []testRecord{{1, "a"}}, result.records)
+	var httpErr *Error
+	require.ErrorAs(t, result.errs[1], &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+}
+
+func TestReadJSONArray_MaxRecordSize(t *testing.T) {
+	first := `{"id":1,"name":"a"}`
+	body := `[` + first + `,{"id":2,"name":"` + strings.Repeat("b", 64) + `"},{"id":3,"name":"c"}]`
+
+	result := collectStream(ReadJSONArray[testRecord](strings.NewReader(body), WithMaxRecordSize(int64(len(first)))))
+
+	assert.Equal(t, []testRecord{{1, "a"}}, result.records)
+	require.Len(t, result.errs, 1, "an element over the limit ends the iteration")
+
+	var httpErr *Error
+	require.ErrorAs(t, result.errs[1], &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	assert.Equal(t, map[string]any{"limit": int64(len(first)), "index": 1}, httpErr.Details)
+}
+
+func TestReadJSONArray_DefaultMaxRecordSize(t *testing.T) {
+	body := `[{"id":1,"name":"` + strings.Repeat("a", DefaultMaxRecordSize) + `"}]`
+
+	result := collectStream(ReadJSONArray[testRecord](strings.NewReader(body)))
+
+	var httpErr *Error
+	require.ErrorAs(t, result.errs[0], &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+}
+
+func TestReadJSONArray_Break(t *testing.T) {
+	body := `[{"id":1,"name":"a"},{"id":2,"name":"b"}]`
+
+	var ids []int
+	for v, err := range ReadJSONArray[testRecord](strings.NewReader(body)) {
+		require.NoError(t, err)
+		ids = append(ids, v.ID)
+		break
+	}
+
+	assert.Equal(t, []int{1}, ids)
+}
+
+func TestReadNDJSON(t *testing.T) {
+	body := "{\"id\":1,\"name\":\"a\"}\n\n{\"id\":2,\"name\":\"b\"}\r\n{\"id\":3,\"name\":\"c\"}"
+
+	result := collectStream(ReadNDJSON[testRecord](strings.NewReader(body)))
+
+	assert.Empty(t, result.errs)
+	assert.Equal(t, []testRecord{{1, "a"}, {2, "b"}, {3, "c"}}, result.records)
+}
+
+func TestReadNDJSON_RecordErrors(t *testing.T) {
+	lines := []string{
+		`{"id":1,"name":"a"}`,
+		``,
+		`{"id":2,`,
+		`{"id":3,"name":"c"} {"id":4}`,
+		`{"id":5}`,
+		`{"id":6,"name":"f"}`,
+	}
+	body := strings.Join(lines, "\n")
+
+	result := collectStream(ReadNDJSON[testRecord](strings.NewReader(body)))
+
+	assert.Equal(t, []testRecord{{1, "a"}, {6, "f"}}, result.records)
+	require.Len(t, result.errs, 3)
+
+	details := requireJSONErrorDetails(t, result.errs[1])
+	assert.Equal(t, 1, *details.Index)
+	assert.Equal(t, 3, details.Line)
+	assert.Equal(t, int64(strings.Index(body, `{"id":2,`)), details.Offset)
+
+	details = requireJSONErrorDetails(t, result.errs[2])
+	assert.Equal(t, 4, details.Line)
+	assert.Equal(t, "unexpected data after JSON value", details.Reason)
+
+	var verr *ValidationError
+	require.ErrorAs(t, result.errs[3], &verr)
+	assert.Equal(t, "[3].name", verr.Errors[0].Field)
+}
+
+func TestReadNDJSON_MaxRecordSize(t *testing.T) {
+	lines := []string{
+		`{"id":1,"name":"a"}`,
+		`{"id":2,"name":"` + strings.Repeat("b", 8192) + `"}`,
+		`{"id":3,"name":"c"}`,
+	}
+	body := strings.Join(lines, "\n")
+
+	result := collectStream(ReadNDJSON[testRecord](strings.NewReader(body), WithMaxRecordSize(int64(len(lines[0])))))
+
+	assert.Equal(t, []testRecord{{1, "a"}, {3, "c"}}, result.records, "a line over the limit does not end the iteration")
+	require.Len(t, result.errs, 1)
+
+	var httpErr *Error
+	require.ErrorAs(t, result.errs[1], &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	assert.Equal(t, map[string]any{"limit": int64(len(lines[0])), "index": 1, "line": 2}, httpErr.Details)
+}
+
+func TestReadNDJSON_DefaultMaxRecordSize(t *testing.T) {
+	body := `{"id":1,"name":"` + strings.Repeat("a", DefaultMaxRecordSize) + `"}` + "\n" + `{"id":2,"name":"b"}`
+
+	result := collectStream(ReadNDJSON[testRecord](strings.NewReader(body)))
+
+	assert.Equal(t, []testRecord{{2, "b"}}, result.records)
+	var httpErr *Error
+	require.ErrorAs(t, result.errs[0], &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+}
+
+func TestReadNDJSON_ReadError(t *testing.T) {
+	body := io.MultiReader(strings.NewReader("{\"id\":1,\"name\":\"a\"}\n"), &errorReader{})
+
+	count := 0
+	var lastErr error
+	for _, err := range ReadNDJSON[testRecord](body) {
+		count++
+		lastErr = err
+	}
+
+	assert.Equal(t, 2, count)
+	var httpErr *Error
+	require.ErrorAs(t, lastErr, &httpErr)
+	assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+}
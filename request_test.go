@@ -3,6 +3,8 @@ package dino
 import (
 	"bytes"
 	"errors"
+	"io"
+	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
@@ -216,3 +218,69 @@ func TestReadJSON_WithExtraFields(t *testing.T) {
 	assert.Equal(t, "john@example.com", result.Email)
 	assert.Equal(t, 30, result.Age)
 }
+
+func TestRead_WithMaxBytes(t *testing.T) {
+	body := `{"name":"John","email":"john@example.com","age":30}`
+
+	tests := []struct {
+		name string
+		read func(r io.Reader, opts ...ReadOption) error
+	}{
+		{"ReadJSON", func(r io.Reader, opts ...ReadOption) error {
+			_, err := ReadJSON[testStruct](r, opts...)
+			return err
+		}},
+		{"ReadXML", func(r io.Reader, opts ...ReadOption) error {
+			_, err := ReadXML[testStruct](r, opts...)
+			return err
+		}},
+		{"ReadBytes", func(r io.Reader, opts ...ReadOption) error {
+			_, err := ReadBytes(r, opts...)
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.read(strings.NewReader(body), WithMaxBytes(10))
+
+			var httpErr *Error
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+			assert.Equal(t, map[string]int64{"limit": 10}, httpErr.Details)
+		})
+	}
+}
+
+func TestReadJSON_WithMaxBytes_WithinLimit(t *testing.T) {
+	body := `{"name":"John"}`
+
+	result, err := ReadJSON[testStruct](strings.NewReader(body), WithMaxBytes(int64(len(body))))
+
+	require.NoError(t, err)
+	assert.Equal(t, "John", result.Name)
+}
+
+func TestReadBytes_WithMaxBytes_ExactLimit(t *testing.T) {
+	data, err := ReadBytes(strings.NewReader("12345"), WithMaxBytes(5))
+
+	require.NoError(t, err)
+	assert.Equal(t, []byte("12345"), data)
+
+	_, err = ReadBytes(strings.NewReader("123456"), WithMaxBytes(5))
+
+	require.Error(t, err)
+}
+
+func TestReadJSON_MaxBytesReaderFromRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/test", strings.NewReader(`{"name":"John Doe"}`))
+	rec := httptest.NewRecorder()
+	req.Body = http.MaxBytesReader(rec, req.Body, 5)
+
+	_, err := ReadJSON[testStruct](req.Body)
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, httpErr.Code)
+	assert.Equal(t, map[string]int64{"limit": 5}, httpErr.Details)
+}
@@ -0,0 +1,323 @@
+package dino
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator is implemented by types that check themselves after being
+// decoded. Field errors returned by Validate, such as a ValidationError or
+// the errors returned by Param, are reported together with the failures of
+// the `validate` tags. Other *Errors are returned as is and any other error is
+// reported as a failure of the whole value
+type Validator interface {
+	Validate() error
+}
+
+// Validate checks v, which is usually a decoded request body, against the
+// `validate` tags of its fields and calls Validate on every Validator found in
+// it, including nested structs and slice elements. The rules of a tag are
+// separated by commas:
+//
+//	type CreateUser struct {
+//		Name  string   `json:"name" validate:"required,max=64"`
+//		Age   int      `json:"age" validate:"min=18,max=130"`
+//		Role  string   `json:"role" validate:"omitempty,oneof=admin member"`
+//		Tags  []string `json:"tags" validate:"max=10"`
+//		Email *string  `json:"email" validate:"required"`
+//	}
+//
+//	required   the value is not the zero value (nor a nil pointer)
+//	omitempty  skips the remaining rules when the value is the zero value
+//	min=n      numbers are >= n, strings have at least n characters and
+//	           slices, arrays and maps at least n items
+//	max=n      like min, but for the upper bound
+//	len=n      strings have exactly n characters and slices, arrays and
+//	           maps exactly n items
+//	oneof=a b  the value is one of the space-separated values
+//
+// Fields are named after their json, xml or form tag. Failures result in a
+// 422 error listing every field. Invalid rules are programming errors and are
+// returned as plain errors
+func Validate(v any) error {
+	return validateAt(v, "")
+}
+
+func validateAt(v any, path string) error {
+	var verr ValidationError
+
+	if err := validateValue(reflect.ValueOf(v), path, &verr); err != nil {
+		return err
+	}
+
+	if verr.HasErrors() {
+		return validationHTTPError(&verr)
+	}
+
+	return nil
+}
+
+func validateValue(rv reflect.Value, path string, verr *ValidationError) error {
+	for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Struct:
+		if err := validateFields(rv, path, verr); err != nil {
+			return err
+		}
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			if err := validateValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i), verr); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return nil
+	}
+
+	return callValidator(rv, path, verr)
+}
+
+func validateFields(rv reflect.Value, path string, verr *ValidationError) error {
+	for i := range rv.NumField() {
+		field := rv.Type().Field(i)
+		fv := rv.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		fieldPath := path
+		if !field.Anonymous {
+			fieldPath = joinFieldPath(path, validateFieldName(field))
+		}
+
+		if rules, ok := field.Tag.Lookup("validate"); ok {
+			if err := validateRules(fv, fieldPath, rules, verr); err != nil {
+				return err
+			}
+		}
+
+		if err := validateValue(fv, fieldPath, verr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateFieldName(field reflect.StructField) string {
+	for _, key := range []string{"json", "xml", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(key), ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+// The pointer receiver is preferred, so Validate methods declared on either
+// the value or the pointer are found
+func callValidator(rv reflect.Value, path string, verr *ValidationError) error {
+	var validator Validator
+
+	if rv.CanAddr() {
+		validator, _ = rv.Addr().Interface().(Validator)
+	} else if rv.CanInterface() {
+		validator, _ = rv.Interface().(Validator)
+	}
+
+	if validator == nil {
+		return nil
+	}
+
+	err := validator.Validate()
+	if err == nil {
+		return nil
+	}
+
+	// Field errors are collected even when wrapped in an *Error, as returned
+	// by Param, while other *Errors are returned as is
+	var collected ValidationError
+	if !collected.Collect(err) {
+		if errors.As(err, new(*Error)) {
+			return err
+		}
+
+		verr.Add(path, "invalid", err.Error(), nil)
+		return nil
+	}
+
+	for _, fe := range collected.Errors {
+		fe.Field = joinFieldPath(path, fe.Field)
+		verr.Errors = append(verr.Errors, fe)
+	}
+
+	return nil
+}
+
+func validateRules(rv reflect.Value, path, rules string, verr *ValidationError) error {
+	for rule := range strings.SplitSeq(rules, ",") {
+		name, arg, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch name {
+		case "":
+			continue
+		case "required":
+			if rv.IsZero() {
+				verr.Errors = append(verr.Errors, FieldError{
+					Field:   path,
+					Code:    "required",
+					Message: "is required",
+					key:     "dino.field.required",
+				})
+				return nil
+			}
+			continue
+		case "omitempty":
+			if rv.IsZero() {
+				return nil
+			}
+			continue
+		}
+
+		v := rv
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return nil
+			}
+			v = v.Elem()
+		}
+
+		fe, err := checkRule(v, name, arg)
+		if err != nil {
+			return fmt.Errorf("dino: invalid validate rule %q on field %s: %w", rule, path, err)
+		}
+
+		if fe != nil {
+			fe.Field = path
+			fe.Code = name
+
+			// Collections are left out, since they may be large and cannot
+			// be rendered in every format
+			switch v.Kind() {
+			case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+			default:
+				fe.Value = v.Interface()
+			}
+
+			verr.Errors = append(verr.Errors, *fe)
+		}
+	}
+
+	return nil
+}
+
+// boundKeys are the message keys of the min, max and len rules for a kind of
+// value
+type boundKeys struct {
+	min, max, len string
+}
+
+var (
+	numberKeys = boundKeys{min: "min", max: "max"}
+	lengthKeys = boundKeys{min: "min_len", max: "max_len", len: "len"}
+	countKeys  = boundKeys{min: "min_count", max: "max_count", len: "count"}
+)
+
+// checkRule returns the field error describing the failure, without its
+// field, code and value, or nil if v satisfies the rule
+func checkRule(v reflect.Value, name, arg string) (*FieldError, error) {
+	if name == "oneof" {
+		options := strings.Fields(arg)
+		if len(options) == 0 {
+			return nil, errors.New("no values")
+		}
+		if slices.Contains(options, fmt.Sprint(v.Interface())) {
+			return nil, nil
+		}
+
+		values := strings.Join(options, ", ")
+		return &FieldError{Message: "must be one of: " + values, key: "dino.field.oneof", args: []any{values}}, nil
+	}
+
+	if name != "min" && name != "max" && name != "len" {
+		return nil, errors.New("unknown rule")
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if name == "len" {
+			return nil, errors.New("len is not supported on numbers")
+		}
+
+		bound, err := strconv.ParseFloat(arg, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return checkBound(name, numberValue(v), bound, "must be %s %v", numberKeys, arg), nil
+
+	case reflect.String:
+		bound, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return checkBound(name, float64(utf8.RuneCountInString(v.String())), float64(bound), "must be %s %v characters long", lengthKeys, bound), nil
+
+	case reflect.Slice, reflect.Array, reflect.Map:
+		bound, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, err
+		}
+
+		return checkBound(name, float64(v.Len()), float64(bound), "must contain %s %v items", countKeys, bound), nil
+	}
+
+	return nil, fmt.Errorf("not supported on %s", v.Type())
+}
+
+func checkBound(name string, value, bound float64, format string, keys boundKeys, arg any) *FieldError {
+	var qualifier, key string
+
+	switch {
+	case name == "min" && value < bound:
+		qualifier, key = "at least", keys.min
+	case name == "max" && value > bound:
+		qualifier, key = "at most", keys.max
+	case name == "len" && value != bound:
+		qualifier, key = "exactly", keys.len
+	default:
+		return nil
+	}
+
+	return &FieldError{
+		Message: fmt.Sprintf(format, qualifier, arg),
+		key:     "dino.field." + key,
+		args:    []any{arg},
+	}
+}
+
+func numberValue(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	}
+	return v.Float()
+}
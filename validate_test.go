@@ -0,0 +1,249 @@
+package dino
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testValidateAddress struct {
+	City string `json:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"len=5"`
+}
+
+type testCreateUser struct {
+	Name     string                `json:"name" validate:"required,max=8"`
+	Age      int                   `json:"age" validate:"min=18,max=130"`
+	Score    float64               `json:"score" validate:"max=1.5"`
+	Role     string                `json:"role" validate:"omitempty,oneof=admin member"`
+	Tags     []string              `json:"tags" validate:"min=1,max=2"`
+	Email    *string               `json:"email" validate:"required,min=3"`
+	Address  testValidateAddress   `json:"address"`
+	Previous []testValidateAddress `json:"previous"`
+}
+
+func (u testCreateUser) Validate() error {
+	if u.Name == "root" {
+		var verr ValidationError
+		verr.Add("name", "reserved", "is reserved", u.Name)
+		return verr.Err()
+	}
+	return nil
+}
+
+func validateFieldErrors(t *testing.T, err error) map[string]string {
+	t.Helper()
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusUnprocessableEntity, httpErr.Code)
+
+	var verr *ValidationError
+	require.ErrorAs(t, err, &verr)
+
+	codes := make(map[string]string)
+	for _, fe := range verr.Errors {
+		codes[fe.Field] = fe.Code + ": " + fe.Message
+	}
+	return codes
+}
+
+func TestValidate_Valid(t *testing.T) {
+	email := "a@b.c"
+
+	err := Validate(&testCreateUser{
+		Name:    "alice",
+		Age:     30,
+		Tags:    []string{"a"},
+		Email:   &email,
+		Address: testValidateAddress{City: "Lisbon", Zip: "12345"},
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestValidate_Rules(t *testing.T) {
+	email := "ab"
+
+	err := Validate(&testCreateUser{
+		Name:     "bartholomew",
+		Age:      12,
+		Score:    2,
+		Role:     "owner",
+		Tags:     []string{"a", "b", "c"},
+		Email:    &email,
+		Address:  testValidateAddress{Zip: "123"},
+		Previous: []testValidateAddress{{City: "Porto", Zip: "12345"}, {Zip: "12345"}},
+	})
+
+	assert.Equal(t, map[string]string{
+		"name":             "max: must be at most 8 characters long",
+		"age":              "min: must be at least 18",
+		"score":            "max: must be at most 1.5",
+		"role":             "oneof: must be one of: admin, member",
+		"tags":             "max: must contain at most 2 items",
+		"email":            "min: must be at least 3 characters long",
+		"address.city":     "required: is required",
+		"address.zip":      "len: must be exactly 5 characters long",
+		"previous[1].city": "required: is required",
+	}, validateFieldErrors(t, err))
+}
+
+func TestValidate_Required(t *testing.T) {
+	err := Validate(&testCreateUser{Name: "alice", Age: 30, Tags: []string{"a"}, Address: testValidateAddress{City: "Lisbon", Zip: "12345"}})
+
+	assert.Equal(t, map[string]string{
+		"email": "required: is required",
+	}, validateFieldErrors(t, err))
+}
+
+func TestValidate_Validator(t *testing.T) {
+	email := "a@b.c"
+
+	err := Validate(&testCreateUser{Name: "root", Age: 30, Tags: []string{"a"}, Email: &email, Address: testValidateAddress{City: "Lisbon", Zip: "12345"}})
+
+	assert.Equal(t, map[string]string{
+		"name": "reserved: is reserved",
+	}, validateFieldErrors(t, err))
+}
+
+type testNested struct {
+	Items []testValidatorItem `json:"items"`
+}
+
+type testValidatorItem struct {
+	Quantity int `json:"quantity"`
+}
+
+func (i *testValidatorItem) Validate() error {
+	switch {
+	case i.Quantity < 0:
+		return errors.New("quantity cannot be negative")
+	case i.Quantity > 100:
+		return Conflict("not enough stock")
+	}
+	return nil
+}
+
+func TestValidate_NestedValidator(t *testing.T) {
+	err := Validate(&testNested{Items: []testValidatorItem{{Quantity: 1}, {Quantity: -1}}})
+
+	assert.Equal(t, map[string]string{
+		"items[1]": "invalid: quantity cannot be negative",
+	}, validateFieldErrors(t, err))
+}
+
+func TestValidate_ValidatorReturningError(t *testing.T) {
+	err := Validate(&testNested{Items: []testValidatorItem{{Quantity: 101}}})
+
+	var httpErr *Error
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+}
+
+func TestValidate_CollectionRulesRenderAsProblemXML(t *testing.T) {
+	type labels struct {
+		Tags   []string          `json:"tags" validate:"max=1"`
+		Labels map[string]string `json:"labels" validate:"max=1"`
+	}
+
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return Validate(labels{Tags: []string{"a", "b"}, Labels: map[string]string{"a": "1", "b": "2"}})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/test", nil)
+	rec := httptest.NewRecorder()
+
+	handler.WithErrorHandler(NewErrorHandler(WithErrorFormat(ErrorFormatProblemXML))).ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Equal(t, `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Unprocessable Entity</title>`+
+		`<status>422</status><detail>Validation failed</detail><errors>`+
+		`<i><field>tags</field><code>max</code><message>must contain at most 1 items</message></i>`+
+		`<i><field>labels</field><code>max</code><message>must contain at most 1 items</message></i>`+
+		`</errors></problem>`, rec.Body.String())
+}
+
+type testParamValidator struct {
+	Name string `json:"name" validate:"required"`
+	Page string `json:"page"`
+}
+
+func (v testParamValidator) Validate() error {
+	req := httptest.NewRequest(http.MethodGet, "/test?page="+v.Page, nil)
+	_, err := QueryParam(req, "page").Int()
+	return err
+}
+
+func TestValidate_ValidatorReturningParamError(t *testing.T) {
+	_, err := ReadJSON[testParamValidator](strings.NewReader(`{"page":"abc"}`))
+
+	assert.Equal(t, map[string]string{
+		"name": "required: is required",
+		"page": "invalid: must be an integer",
+	}, validateFieldErrors(t, err))
+}
+
+func TestValidate_InvalidRule(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+	}{
+		{"unknown rule", &struct {
+			Name string `validate:"email"`
+		}{}},
+		{"invalid bound", &struct {
+			Age int `validate:"min=ten"`
+		}{}},
+		{"len on number", &struct {
+			Age int `validate:"len=2"`
+		}{}},
+		{"empty oneof", &struct {
+			Role string `validate:"oneof="`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.value)
+
+			require.Error(t, err)
+			var httpErr *Error
+			assert.NotErrorAs(t, err, &httpErr, "programming errors are not client errors")
+		})
+	}
+}
+
+func TestReadJSON_Validates(t *testing.T) {
+	body := `{"name":"alice","age":10,"tags":["a"],"email":"a@b.c","address":{"city":"Lisbon","zip":"12345"}}`
+
+	_, err := ReadJSON[testCreateUser](strings.NewReader(body))
+	assert.Equal(t, map[string]string{
+		"age": "min: must be at least 18",
+	}, validateFieldErrors(t, err))
+
+	user, err := ReadJSON[testCreateUser](strings.NewReader(body), WithoutValidation())
+	require.NoError(t, err)
+	assert.Equal(t, 10, user.Age)
+}
+
+func TestBind_Validates(t *testing.T) {
+	req := newBindRequest("application/x-www-form-urlencoded", "name=&email=a@b.c&age=20")
+
+	_, err := Bind[testBindValidated](req)
+
+	assert.Equal(t, map[string]string{
+		"name": "required: is required",
+	}, validateFieldErrors(t, err))
+}
+
+type testBindValidated struct {
+	Name  string `form:"name" validate:"required"`
+	Email string `form:"email"`
+	Age   int    `form:"age" validate:"min=18"`
+}
@@ -0,0 +1,94 @@
+package dino
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// FieldError describes why a single field of the request was rejected. Field
+// is the path of the field, e.g. "page" or "items[0].name"
+type FieldError struct {
+	Field   string `json:"field" xml:"field"`
+	Code    string `json:"code" xml:"code"`
+	Message string `json:"message" xml:"message"`
+	Value   any    `json:"value,omitempty" xml:"value,omitempty"`
+
+	key  string
+	args []any
+}
+
+func (fe *FieldError) Error() string {
+	if fe.Field == "" {
+		return fe.Message
+	}
+	return fe.Field + " " + fe.Message
+}
+
+// ValidationError accumulates field errors so all of them can be reported in a
+// single response. It is rendered as a 422 with the following layout:
+//
+//	JSON:          {"code":422,"message":"Validation failed","details":{"errors":[{"field","code","message","value"}]}}
+//	Problem JSON:  {..., "errors":[{"field","code","message","value"}]}
+//	Problem XML:   <problem ...><errors><i><field/><code/><message/><value/></i></errors></problem>
+type ValidationError struct {
+	Errors []FieldError `json:"errors" xml:"error"`
+}
+
+func (v *ValidationError) Add(field, code, message string, value any) {
+	v.Errors = append(v.Errors, FieldError{
+		Field:   field,
+		Code:    code,
+		Message: message,
+		Value:   value,
+	})
+}
+
+// Collect adds the field errors found in err's chain, which includes errors
+// returned by Param and other ValidationErrors. It reports whether err was
+// collected. Errors that do not describe a field are not collected and should
+// be returned as is
+func (v *ValidationError) Collect(err error) bool {
+	var other *ValidationError
+	if errors.As(err, &other) {
+		v.Errors = append(v.Errors, other.Errors...)
+		return true
+	}
+
+	var fe *FieldError
+	if errors.As(err, &fe) {
+		v.Errors = append(v.Errors, *fe)
+		return true
+	}
+
+	return false
+}
+
+func (v *ValidationError) HasErrors() bool {
+	return len(v.Errors) > 0
+}
+
+// Err returns nil when no error was added, so it can be returned directly at
+// the end of a chain of checks
+func (v *ValidationError) Err() error {
+	if !v.HasErrors() {
+		return nil
+	}
+	return v
+}
+
+func (v *ValidationError) Error() string {
+	msgs := make([]string, len(v.Errors))
+	for i := range v.Errors {
+		msgs[i] = v.Errors[i].Error()
+	}
+	return "validation failed: " + strings.Join(msgs, "; ")
+}
+
+func (v *ValidationError) toError(code int, message string, opts ...ErrorOption) *Error {
+	return NewError(code, message, append([]ErrorOption{WithDetails(v), WithInternalError(v)}, opts...)...)
+}
+
+func validationHTTPError(v *ValidationError) *Error {
+	return v.toError(http.StatusUnprocessableEntity, "Validation failed", WithMessageKey("dino.validation_failed"))
+}
@@ -0,0 +1,122 @@
+package dino
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidationError_Add(t *testing.T) {
+	var v ValidationError
+
+	v.Add("email", "invalid", "must be an email address", "john")
+	v.Add("age", "min", "must be at least 18", 12)
+
+	require.True(t, v.HasErrors())
+	assert.Equal(t, []FieldError{
+		{Field: "email", Code: "invalid", Message: "must be an email address", Value: "john"},
+		{Field: "age", Code: "min", Message: "must be at least 18", Value: 12},
+	}, v.Errors)
+	assert.Equal(t, "validation failed: email must be an email address; age must be at least 18", v.Error())
+}
+
+func TestValidationError_Err(t *testing.T) {
+	var v ValidationError
+
+	assert.NoError(t, v.Err())
+
+	v.Add("name", "required", "is required", nil)
+
+	var target *ValidationError
+	require.ErrorAs(t, v.Err(), &target)
+	assert.Same(t, &v, target)
+}
+
+func TestValidationError_CollectParams(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/test?page=abc&limit=10", nil)
+
+	var v ValidationError
+
+	_, err := QueryParam(req, "page").Int()
+	assert.True(t, v.Collect(err))
+
+	_, err = QueryParam(req, "limit").Int()
+	assert.False(t, v.Collect(err))
+
+	_, err = RequiredQueryParam(req, "sort")
+	assert.True(t, v.Collect(err))
+
+	assert.Equal(t, []FieldError{
+		{Field: "page", Code: "invalid", Message: "must be an integer", Value: "abc", key: "dino.field.int"},
+		{Field: "sort", Code: "required", Message: "is required", Value: "", key: "dino.field.required"},
+	}, v.Errors)
+}
+
+func TestValidationError_CollectValidationError(t *testing.T) {
+	var body ValidationError
+	body.Add("name", "required", "is required", nil)
+
+	var v ValidationError
+	v.Add("page", "invalid", "must be an integer", "abc")
+
+	assert.True(t, v.Collect(body.Err()))
+	assert.Len(t, v.Errors, 2)
+}
+
+func TestValidationError_CollectOtherErrors(t *testing.T) {
+	var v ValidationError
+
+	assert.False(t, v.Collect(errors.New("database down")))
+	assert.False(t, v.Collect(NewError(http.StatusNotFound, "not found")))
+	assert.False(t, v.HasErrors())
+}
+
+func TestValidationError_Rendering(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		var v ValidationError
+		v.Add("email", "invalid", "must be an email address", "john")
+		return v.Err()
+	})
+
+	tests := []struct {
+		name         string
+		format       ErrorFormat
+		expectedBody string
+	}{
+		{
+			name:   "json",
+			format: ErrorFormatJSON,
+			expectedBody: `{"code":422,"message":"Validation failed","details":{"errors":[` +
+				`{"field":"email","code":"invalid","message":"must be an email address","value":"john"}]}}` + "\n",
+		},
+		{
+			name:   "problem json",
+			format: ErrorFormatProblemJSON,
+			expectedBody: `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"Validation failed","errors":[` +
+				`{"field":"email","code":"invalid","message":"must be an email address","value":"john"}]}` + "\n",
+		},
+		{
+			name:   "problem xml",
+			format: ErrorFormatProblemXML,
+			expectedBody: `<problem xmlns="urn:ietf:rfc:7807"><type>about:blank</type><title>Unprocessable Entity</title>` +
+				`<status>422</status><detail>Validation failed</detail><errors><i><field>email</field><code>invalid</code>` +
+				`<message>must be an email address</message><value>john</value></i></errors></problem>`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/test", nil)
+			rec := httptest.NewRecorder()
+
+			handler.WithErrorHandler(NewErrorHandler(WithErrorFormat(tt.format))).ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+			assert.Equal(t, tt.expectedBody, rec.Body.String())
+		})
+	}
+}